@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jdpolicano/aof-go/internal"
+)
+
+// explain parses a day's input and prints the solver's own account of it.
+func explain(args []string) error {
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to explain")
+	input, example := inputFlags(fs)
+	fs.Parse(args)
+
+	d, ok := internal.Lookup(*day)
+	if !ok {
+		return fmt.Errorf("day %d is not registered", *day)
+	}
+	data, err := d.ReadInput(*input, int(*example))
+	if err != nil {
+		return err
+	}
+	parsed, err := d.Parse(data)
+	if err != nil {
+		return fmt.Errorf("day %d: %w", d.Number, err)
+	}
+	w := bufio.NewWriter(os.Stdout)
+	if err := d.Explain(w, parsed); err != nil {
+		w.Flush()
+		return err
+	}
+	return w.Flush()
+}
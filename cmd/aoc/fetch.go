@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/jdpolicano/aof-go/internal"
+	"github.com/jdpolicano/aof-go/internal/client"
+)
+
+// defaultYear is the event this repository solves.
+const defaultYear = 2024
+
+// fetch downloads a day's input into days/dayN/input.txt.
+func fetch(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to download")
+	year := fs.Int("year", defaultYear, "event year")
+	force := fs.Bool("force", false, "overwrite an existing input.txt")
+	fs.Parse(args)
+
+	root, err := internal.Root()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(root, internal.DaysDir, "day"+strconv.Itoa(*day))
+	path := filepath.Join(dir, "input.txt")
+	if _, err := os.Stat(path); err == nil && !*force {
+		return fmt.Errorf("%s already exists, use -force to replace it", path)
+	}
+
+	session, err := client.LoadSession()
+	if err != nil {
+		return err
+	}
+	c := client.New(session)
+	b, err := c.Input(context.Background(), *year, *day)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s (%d bytes)\n", path, len(b))
+	return nil
+}
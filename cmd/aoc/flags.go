@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+)
+
+// exampleFlag is an int flag that may also be given bare, so that
+// "-example" selects the first example and "-example=2" the second.
+type exampleFlag int
+
+func (e *exampleFlag) String() string {
+	return strconv.Itoa(int(*e))
+}
+
+func (e *exampleFlag) Set(s string) error {
+	switch s {
+	case "true":
+		*e = 1
+	case "false":
+		*e = 0
+	default:
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid example %q", s)
+		}
+		*e = exampleFlag(n)
+	}
+	return nil
+}
+
+func (e *exampleFlag) IsBoolFlag() bool {
+	return true
+}
+
+// inputFlags registers the flags selecting which input a day runs against.
+func inputFlags(fs *flag.FlagSet) (*string, *exampleFlag) {
+	input := inputFlag(fs)
+	example := new(exampleFlag)
+	fs.Var(example, "example", "use the day's example input instead (-example=N for test_inputN.txt)")
+	return input, example
+}
+
+// inputFlag registers -input alone, for commands that must never run
+// against an example.
+func inputFlag(fs *flag.FlagSet) *string {
+	return fs.String("input", "", "input file, or - for stdin (defaults to the day's input.txt)")
+}
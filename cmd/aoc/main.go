@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jdpolicano/aof-go/internal"
+)
+
+const usage = `usage: aoc <command> [flags]
+
+commands:
+  run     solve a day's puzzle
+  verify  rerun every day and compare against recorded answers
+  explain describe how a day reaches its answers
+  new     scaffold the package for a new day
+  fetch   download a day's input
+  submit  submit an answer and remember the verdict
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = run(os.Args[2:])
+	case "verify":
+		err = verify(os.Args[2:])
+	case "explain":
+		err = explain(os.Args[2:])
+	case "new":
+		err = newDay(os.Args[2:])
+	case "fetch":
+		err = fetch(os.Args[2:])
+	case "submit":
+		err = submit(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "aoc:", err)
+		os.Exit(1)
+	}
+}
+
+// answersFlag registers the flag selecting the known-answer store.
+func answersFlag(fs *flag.FlagSet) *string {
+	return fs.String("answers", "", "known-answer store (defaults to answers.json in the module root)")
+}
+
+func openAnswers(path string) (*internal.AnswerStore, error) {
+	if path == "" {
+		p, err := internal.DefaultAnswersPath()
+		if err != nil {
+			return nil, err
+		}
+		path = p
+	}
+	return internal.OpenAnswers(path)
+}
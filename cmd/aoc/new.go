@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/jdpolicano/aof-go/internal"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+type scaffold struct {
+	Module string
+	Day    int
+	Days   []int
+}
+
+// newDay creates days/dayN with a registered solver skeleton, an empty
+// example input and a test stub, then regenerates the imports in days.go.
+func newDay(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to create")
+	fs.Parse(args)
+
+	if *day < 1 || *day > 25 {
+		return fmt.Errorf("day must be between 1 and 25, got %d", *day)
+	}
+	root, err := internal.Root()
+	if err != nil {
+		return err
+	}
+	module, err := modulePath(root)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(root, internal.DaysDir, "day"+strconv.Itoa(*day))
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("%s already exists", dir)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	s := scaffold{Module: module, Day: *day}
+	name := "day" + strconv.Itoa(*day)
+	if err := render(filepath.Join(dir, name+".go"), "day.go.tmpl", s); err != nil {
+		return err
+	}
+	if err := render(filepath.Join(dir, name+"_test.go"), "day_test.go.tmpl", s); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "test_input.txt"), nil, 0o644); err != nil {
+		return err
+	}
+
+	s.Days, err = scaffoldedDays(root)
+	if err != nil {
+		return err
+	}
+	if err := render(filepath.Join(root, "cmd", "aoc", "days.go"), "days.go.tmpl", s); err != nil {
+		return err
+	}
+	fmt.Printf("created %s\n", dir)
+	return nil
+}
+
+// render executes the named template and writes it gofmt'ed to path.
+func render(path, name string, data scaffold) error {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("render() %s: %w", name, err)
+	}
+	return os.WriteFile(path, src, 0o644)
+}
+
+// scaffoldedDays lists the days that have a package under days/.
+func scaffoldedDays(root string) ([]int, error) {
+	entries, err := os.ReadDir(filepath.Join(root, internal.DaysDir))
+	if err != nil {
+		return nil, err
+	}
+	days := make([]int, 0, len(entries))
+	for _, e := range entries {
+		n, err := strconv.Atoi(strings.TrimPrefix(e.Name(), "day"))
+		if !e.IsDir() || err != nil {
+			continue
+		}
+		sources, _ := filepath.Glob(filepath.Join(root, internal.DaysDir, e.Name(), "*.go"))
+		if len(sources) > 0 {
+			days = append(days, n)
+		}
+	}
+	slices.Sort(days)
+	return days, nil
+}
+
+func modulePath(root string) (string, error) {
+	f, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(sc.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`), nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return "", err
+	}
+	return "", errors.New("modulePath() go.mod has no module directive")
+}
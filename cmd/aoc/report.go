@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jdpolicano/aof-go/internal"
+)
+
+// reportVersion is bumped whenever a field of the JSON report changes meaning
+// or is removed. Adding fields does not bump it.
+const reportVersion = 1
+
+// dayReport is everything the runner learned about one day.
+type dayReport struct {
+	day      int
+	inputErr error
+	result   internal.Result
+	notes    map[int]string          // per part, e.g. a changed answer
+	recorded map[int]internal.Answer // per part, the answer known before this run
+}
+
+type jsonReport struct {
+	Version int          `json:"version"`
+	Results []jsonResult `json:"results"`
+}
+
+// jsonResult is one solved part. Every field is always present so consumers
+// can rely on the shape; a failed part carries its message in Error.
+type jsonResult struct {
+	Day           int    `json:"day"`
+	Part          int    `json:"part"`
+	Answer        string `json:"answer"`
+	InputSHA256   string `json:"input_sha256"`
+	ParseNanos    int64  `json:"parse_ns"`
+	SolveNanos    int64  `json:"solve_ns"`
+	Allocs        uint64 `json:"allocs"`
+	AllocBytes    uint64 `json:"alloc_bytes"`
+	PeakHeapBytes uint64 `json:"peak_heap_bytes"`
+	Error         string `json:"error"`
+	// RecordedAnswer is the answer the store held for this input before the
+	// run, empty if none. It differing from Answer means the answer drifted.
+	RecordedAnswer string `json:"recorded_answer"`
+}
+
+func writeJSON(w io.Writer, reports []dayReport, parts []int) error {
+	out := jsonReport{Version: reportVersion, Results: make([]jsonResult, 0, len(reports)*len(parts))}
+	for _, r := range reports {
+		base := jsonResult{
+			Day:         r.day,
+			InputSHA256: r.result.InputHash,
+			ParseNanos:  r.result.Parse.Duration.Nanoseconds(),
+		}
+		if err := firstErr(r.inputErr, r.result.ParseErr); err != nil {
+			for _, p := range parts {
+				res := base
+				res.Part, res.Error = p, err.Error()
+				out.Results = append(out.Results, res)
+			}
+			continue
+		}
+		for _, pr := range r.result.Parts {
+			res := base
+			res.Part = pr.Part
+			res.Answer = pr.Answer.String()
+			res.RecordedAnswer = r.recorded[pr.Part].String()
+			res.SolveNanos = pr.Stats.Duration.Nanoseconds()
+			res.Allocs = pr.Stats.Allocs
+			res.AllocBytes = pr.Stats.AllocBytes
+			res.PeakHeapBytes = pr.Stats.PeakHeap
+			if pr.Err != nil {
+				res.Error = pr.Err.Error()
+			}
+			out.Results = append(out.Results, res)
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func writeTable(w io.Writer, reports []dayReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "DAY\tPHASE\tANSWER\tTIME\tALLOCS\tALLOC BYTES\tPEAK HEAP\t\t")
+	for _, r := range reports {
+		if r.inputErr != nil {
+			fmt.Fprintf(tw, "%d\tinput\t\t\t\t\t\t%v\n", r.day, r.inputErr)
+			continue
+		}
+		fmt.Fprintf(tw, "%d\tparse\t\t%s\t\t%s\n", r.day, statsColumns(r.result.Parse), errString(r.result.ParseErr))
+		for _, pr := range r.result.Parts {
+			note := r.notes[pr.Part]
+			if pr.Err != nil {
+				note = pr.Err.Error()
+			}
+			fmt.Fprintf(tw, "%d\tpart %d\t%s\t%s\t\t%s\n", r.day, pr.Part, pr.Answer, statsColumns(pr.Stats), note)
+		}
+	}
+	return tw.Flush()
+}
+
+func firstErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// statsColumns formats the time and memory columns of the table.
+func statsColumns(s internal.Stats) string {
+	return fmt.Sprintf("%s\t%d\t%s\t%s", formatDuration(s.Duration), s.Allocs, formatBytes(s.AllocBytes), formatBytes(s.PeakHeap))
+}
+
+func formatDuration(d time.Duration) string {
+	switch {
+	case d < time.Millisecond:
+		return d.Round(time.Microsecond).String()
+	case d < time.Second:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.Round(time.Millisecond).String()
+	}
+}
+
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
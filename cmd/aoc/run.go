@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jdpolicano/aof-go/internal"
+)
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to solve")
+	all := fs.Bool("all", false, "solve every registered day")
+	part := fs.Int("part", 0, "part to solve (0 runs both parts)")
+	input, example := inputFlags(fs)
+	answers := answersFlag(fs)
+	record := fs.Bool("record", false, "record the answers as accepted")
+	asJSON := fs.Bool("json", false, "print results as JSON")
+	fs.Parse(args)
+
+	var days []*internal.Day
+	switch {
+	case *all && *input != "":
+		return errors.New("-all cannot be combined with -input")
+	case *all:
+		days = internal.Days()
+	default:
+		d, ok := internal.Lookup(*day)
+		if !ok {
+			return fmt.Errorf("day %d is not registered", *day)
+		}
+		days = []*internal.Day{d}
+	}
+	parts := []int{1, 2}
+	if *part != 0 {
+		parts = []int{*part}
+	}
+	store, err := openAnswers(*answers)
+	if err != nil {
+		return err
+	}
+
+	reports := make([]dayReport, 0, len(days))
+	failed, changed := false, false
+	for _, d := range days {
+		report := dayReport{day: d.Number, notes: make(map[int]string), recorded: make(map[int]internal.Answer)}
+		data, err := d.ReadInput(*input, int(*example))
+		if err != nil {
+			report.inputErr = err
+			reports = append(reports, report)
+			failed = true
+			continue
+		}
+		report.result = d.Run(data, parts...)
+		if report.result.ParseErr != nil {
+			failed = true
+		}
+		for _, pr := range report.result.Parts {
+			switch {
+			case errors.Is(pr.Err, internal.ErrNotImplemented):
+			case pr.Err != nil:
+				failed = true
+			default:
+				if known, ok := store.Lookup(d.Number, pr.Part, report.result.InputHash); ok {
+					report.recorded[pr.Part] = known
+					if known != pr.Answer {
+						report.notes[pr.Part] = fmt.Sprintf("recorded answer was %s", known)
+					}
+				}
+				if *record && store.Record(d.Number, pr.Part, report.result.InputHash, pr.Answer) {
+					changed = true
+				}
+			}
+		}
+		reports = append(reports, report)
+	}
+
+	if *asJSON {
+		err = writeJSON(os.Stdout, reports, parts)
+	} else {
+		err = writeTable(os.Stdout, reports)
+	}
+	if err != nil {
+		return err
+	}
+	if changed {
+		if err := store.Save(); err != nil {
+			return err
+		}
+	}
+	if failed {
+		return errors.New("some parts failed")
+	}
+	return nil
+}
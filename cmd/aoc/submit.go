@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/jdpolicano/aof-go/internal"
+	"github.com/jdpolicano/aof-go/internal/client"
+)
+
+// submit posts an answer for a day and part. Without -answer the day is
+// solved against its input first, and a correct answer is recorded in the
+// known-answer store.
+func submit(args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to submit")
+	part := fs.Int("part", 0, "part to submit")
+	year := fs.Int("year", defaultYear, "event year")
+	answer := fs.String("answer", "", "answer to submit (defaults to solving the day)")
+	// no -example: an example answer must not reach the site or the store.
+	input := inputFlag(fs)
+	answers := answersFlag(fs)
+	fs.Parse(args)
+
+	if *part != 1 && *part != 2 {
+		return fmt.Errorf("-part must be 1 or 2")
+	}
+	session, err := client.LoadSession()
+	if err != nil {
+		return err
+	}
+	c := client.New(session)
+	if c.History, err = client.LoadHistory(c.DefaultHistoryPath()); err != nil {
+		return err
+	}
+
+	hash := ""
+	if *answer == "" {
+		d, ok := internal.Lookup(*day)
+		if !ok {
+			return fmt.Errorf("day %d is not registered", *day)
+		}
+		data, err := d.ReadInput(*input, 0)
+		if err != nil {
+			return err
+		}
+		res := d.Run(data, *part)
+		if res.ParseErr != nil {
+			return fmt.Errorf("day %d: %w", *day, res.ParseErr)
+		}
+		if err := res.Parts[0].Err; err != nil {
+			return fmt.Errorf("day %d part %d: %w", *day, *part, err)
+		}
+		*answer, hash = res.Parts[0].Answer.String(), res.InputHash
+	}
+
+	v, err := c.Submit(context.Background(), *year, *day, *part, *answer)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("day %d part %d: %s is %s\n", *day, *part, *answer, v.Outcome)
+	if v.Wait > 0 {
+		fmt.Printf("wait %s before the next attempt\n", v.Wait)
+	}
+	if v.Outcome == client.Unknown {
+		fmt.Println(v.Message)
+	}
+	if v.Outcome != client.Correct || hash == "" {
+		return nil
+	}
+	store, err := openAnswers(*answers)
+	if err != nil {
+		return err
+	}
+	if store.Record(*day, *part, hash, internal.Answer(*answer)) {
+		return store.Save()
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jdpolicano/aof-go/internal"
+)
+
+// verify reruns every registered day against every input in its directory
+// and fails when an answer no longer matches the recorded one.
+func verify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	answers := answersFlag(fs)
+	fs.Parse(args)
+
+	store, err := openAnswers(*answers)
+	if err != nil {
+		return err
+	}
+	mismatches := 0
+	for _, d := range internal.Days() {
+		paths, err := d.Inputs()
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			mismatches += verifyInput(store, d, path)
+		}
+	}
+	if mismatches > 0 {
+		return fmt.Errorf("%d answers changed", mismatches)
+	}
+	return nil
+}
+
+func verifyInput(store *internal.AnswerStore, d *internal.Day, path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Printf("FAIL day %d %s: %v\n", d.Number, filepath.Base(path), err)
+		return 1
+	}
+	hash := internal.HashInput(data)
+	parsed, parseErr := d.Parse(data)
+	mismatches := 0
+	for part := 1; part <= 2; part++ {
+		known, recorded := store.Lookup(d.Number, part, hash)
+		if !recorded {
+			continue
+		}
+		label := fmt.Sprintf("day %d part %d %s", d.Number, part, filepath.Base(path))
+		if parseErr != nil {
+			fmt.Printf("FAIL %s: %v\n", label, parseErr)
+			mismatches++
+			continue
+		}
+		answer, err := d.Solve(part, parsed)
+		switch {
+		case err != nil && !errors.Is(err, internal.ErrNotImplemented):
+			fmt.Printf("FAIL %s: %v\n", label, err)
+			mismatches++
+		case err != nil || answer != known:
+			fmt.Printf("FAIL %s: got %q, recorded %q\n", label, answer, known)
+			mismatches++
+		default:
+			fmt.Printf("ok   %s: %s\n", label, answer)
+		}
+	}
+	return mismatches
+}
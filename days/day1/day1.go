@@ -0,0 +1,63 @@
+package day1
+
+import (
+	"slices"
+
+	"github.com/jdpolicano/aof-go/internal"
+)
+
+func init() {
+	internal.Register(1, Solver{})
+}
+
+type Lists struct {
+	left  []int
+	right []int
+}
+
+type Solver struct{}
+
+func (Solver) Parse(input []byte) (Lists, error) {
+	leftNums := make([]int, 0, 1024)
+	rightNums := make([]int, 0, 1024)
+	scanner := internal.NewIntScanner(input, " \t")
+	row, ok := scanner.ScanLine(nil)
+	for ; ok; row, ok = scanner.ScanLine(row[:0]) {
+		if len(row) == 0 {
+			continue
+		}
+		if len(row) != 2 {
+			return Lists{}, scanner.Errorf("expected 2 numbers, found %d", len(row))
+		}
+		leftNums = append(leftNums, row[0])
+		rightNums = append(rightNums, row[1])
+	}
+	if err := scanner.Err(); err != nil {
+		return Lists{}, err
+	}
+	return Lists{leftNums, rightNums}, nil
+}
+
+func (Solver) Part1(lists Lists) (internal.Answer, error) {
+	left, right := slices.Clone(lists.left), slices.Clone(lists.right)
+	slices.Sort(left)
+	slices.Sort(right)
+
+	totalDistance := 0
+	for i := range left {
+		diff := left[i] - right[i]
+		if diff < 0 {
+			diff = -diff
+		}
+		totalDistance += diff
+	}
+	return internal.IntAnswer(totalDistance), nil
+}
+
+func (Solver) Part2(lists Lists) (internal.Answer, error) {
+	occurances := internal.CounterOf(slices.Values(lists.right))
+	similiarityScore := internal.Reduce(slices.Values(lists.left), 0, func(acc, num int) int {
+		return acc + num*occurances.Count(num)
+	})
+	return internal.IntAnswer(similiarityScore), nil
+}
@@ -0,0 +1,106 @@
+package day2
+
+import (
+	"iter"
+	"slices"
+
+	collections "github.com/jdpolicano/aof-go/internal"
+)
+
+func init() {
+	collections.Register(2, Solver{})
+}
+
+type Solver struct{}
+
+func (Solver) Parse(input []byte) ([][]int, error) {
+	asNums := make([][]int, 0, 1024)
+	scanner := collections.NewIntScanner(input, " \t")
+	for {
+		row, ok := scanner.ScanLine(nil)
+		if !ok {
+			break
+		}
+		if len(row) > 0 {
+			asNums = append(asNums, row)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return asNums, nil
+}
+
+func (Solver) Part1(asNums [][]int) (collections.Answer, error) {
+	safe := collections.Filter(slices.Values(asNums), testRow)
+	return collections.IntAnswer(collections.Count(safe)), nil
+}
+
+func (Solver) Part2(asNums [][]int) (collections.Answer, error) {
+	safe := collections.Filter(slices.Values(asNums), func(r []int) bool {
+		for candidate := range getAllPossibleRows(r) {
+			if testRow(candidate) {
+				return true
+			}
+		}
+		return false
+	})
+	return collections.IntAnswer(collections.Count(safe)), nil
+}
+
+func isStrictIncreasing(row []int) bool {
+	return row[0] > row[1]
+}
+
+func isStrictDecreasing(row []int) bool {
+	return row[0] < row[1]
+}
+
+// getAllPossibleRows yields the row itself and then the row with each element
+// dropped in turn. The candidate slice is reused between iterations.
+func getAllPossibleRows(row []int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if !yield(row) {
+			return
+		}
+		r := make([]int, len(row)-1)
+		for i := range row {
+			// build up an array excluding the current element
+			copy(r, row[:i])
+			copy(r[i:], row[i+1:])
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// testRow reports whether the levels of row all change in one direction by
+// 1 to 3. A row of fewer than two levels has no changes and is safe.
+func testRow(row []int) bool {
+	if len(row) < 2 {
+		return true
+	}
+	if isStrictIncreasing(row) {
+		return isSafe(row, func(a, b int) int { return a - b })
+	}
+
+	if isStrictDecreasing(row) {
+		return isSafe(row, func(a, b int) int { return b - a })
+	}
+
+	return false
+}
+
+func isSafe(n []int, f func(i, j int) int) bool {
+	for pair := range collections.Windows(slices.Values(n), 2) {
+		if !inRange(1, 3, f(pair[0], pair[1])) {
+			return false
+		}
+	}
+	return true
+}
+
+func inRange(i, j, k int) bool {
+	return i <= k && k <= j
+}
@@ -0,0 +1,32 @@
+package day2
+
+import (
+	"testing"
+
+	"github.com/jdpolicano/aof-go/internal"
+)
+
+func TestShortReports(t *testing.T) {
+	tests := []struct {
+		input        string
+		part1, part2 internal.Answer
+	}{
+		{"5\n", "1", "1"},
+		{"5 5\n", "0", "1"},
+		{"5 6\n", "1", "1"},
+		{"5 9\n", "0", "1"},
+		{"7 6 4 2 1\n1 2 7 8 9\n1 3 2 4 5\n", "1", "2"},
+	}
+	for _, tt := range tests {
+		rows, err := Solver{}.Parse([]byte(tt.input))
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.input, err)
+		}
+		if got, _ := (Solver{}).Part1(rows); got != tt.part1 {
+			t.Errorf("Part1(%q) = %s, want %s", tt.input, got, tt.part1)
+		}
+		if got, _ := (Solver{}).Part2(rows); got != tt.part2 {
+			t.Errorf("Part2(%q) = %s, want %s", tt.input, got, tt.part2)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package day3
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/jdpolicano/aof-go/internal"
+)
+
+func init() {
+	internal.Register(3, Solver{})
+}
+
+const (
+	Mul = iota
+	Do
+	Dont
+)
+
+type Instruction struct {
+	op int
+	n1 int
+	n2 int
+}
+
+type Solver struct{}
+
+func (Solver) Parse(input []byte) ([]Instruction, error) {
+	re := regexp.MustCompile("do\\(\\)|don't\\(\\)|mul\\((\\d{1,3}),(\\d{1,3})\\)")
+	matches := re.FindAllSubmatch(input, -1)
+	instructions := make([]Instruction, 0, len(matches))
+	for _, match := range matches {
+		if string(match[0]) == "do()" {
+			instructions = append(instructions, Instruction{op: Do})
+			continue
+		}
+		if string(match[0]) == "don't()" {
+			instructions = append(instructions, Instruction{op: Dont})
+			continue
+		}
+		n1, e1 := strconv.Atoi(string(match[1]))
+		if e1 != nil {
+			return nil, fmt.Errorf("regex failed to parse first number correctly from %s", match[0])
+		}
+		n2, e2 := strconv.Atoi(string(match[2]))
+		if e2 != nil {
+			return nil, fmt.Errorf("regex failed to parse second number correctly from %s", match[0])
+		}
+		instructions = append(instructions, Instruction{Mul, n1, n2})
+	}
+	return instructions, nil
+}
+
+func (Solver) Part1(instructions []Instruction) (internal.Answer, error) {
+	sums := 0
+	for _, in := range instructions {
+		if in.op == Mul {
+			sums += in.n1 * in.n2
+		}
+	}
+	return internal.IntAnswer(sums), nil
+}
+
+func (Solver) Part2(instructions []Instruction) (internal.Answer, error) {
+	sums := 0
+	apply := true
+	for _, in := range instructions {
+		switch in.op {
+		case Do:
+			apply = true
+		case Dont:
+			apply = false
+		case Mul:
+			if apply {
+				sums += in.n1 * in.n2
+			}
+		}
+	}
+	return internal.IntAnswer(sums), nil
+}
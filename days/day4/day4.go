@@ -0,0 +1,47 @@
+package day4
+
+import (
+	"github.com/jdpolicano/aof-go/internal"
+)
+
+func init() {
+	internal.Register(4, Solver{})
+}
+
+// The word search templates. Every rotation and reflection is searched, so
+// one straight and one diagonal XMAS cover all eight reading directions.
+var (
+	straightXMAS = mustPattern("XMAS")
+	diagonalXMAS = mustPattern(`
+X...
+.M..
+..A.
+...S`)
+	crossMAS = mustPattern(`
+M.S
+.A.
+M.S`)
+)
+
+func mustPattern(s string) *internal.Pattern[byte] {
+	p, err := internal.ParsePattern(s, '.')
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+type Solver struct{}
+
+func (Solver) Parse(input []byte) (*internal.Grid[byte], error) {
+	return internal.ParseGrid(input)
+}
+
+func (Solver) Part1(grid *internal.Grid[byte]) (internal.Answer, error) {
+	count := len(internal.FindPattern(grid, straightXMAS)) + len(internal.FindPattern(grid, diagonalXMAS))
+	return internal.IntAnswer(count), nil
+}
+
+func (Solver) Part2(grid *internal.Grid[byte]) (internal.Answer, error) {
+	return internal.IntAnswer(len(internal.FindPattern(grid, crossMAS))), nil
+}
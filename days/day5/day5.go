@@ -0,0 +1,296 @@
+package day5
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jdpolicano/aof-go/internal"
+	"github.com/jdpolicano/aof-go/internal/graph"
+)
+
+func init() {
+	internal.Register(5, Solver{})
+}
+
+// The input is a section of rules, a blank line, then a section of updates.
+const (
+	InRules = iota
+	InUpdates
+)
+
+// Rule says page Former must be printed before page Latter.
+type Rule struct {
+	Former int
+	Latter int
+}
+
+func (r Rule) String() string {
+	return fmt.Sprintf("%d|%d", r.Former, r.Latter)
+}
+
+type Update struct {
+	Pages []int
+}
+
+var (
+	ruleFormat   = internal.MustFormat("%d|%d")
+	updateFormat = internal.MustFormat("%d,...")
+)
+
+func (u *Update) Middle() int {
+	if u == nil {
+		return 0
+	}
+	n := len(u.Pages)
+	return u.Pages[n/2]
+}
+
+type Parser struct {
+	rules   []Rule
+	updates []Update
+	scanner *bufio.Scanner
+	section int
+	line    int
+}
+
+func NewParser(r io.Reader) *Parser {
+	rules := make([]Rule, 0, 1024)
+	updates := make([]Update, 0, 1024)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), 1<<20)
+	return &Parser{rules, updates, scanner, InRules, 0}
+}
+
+// Parse reads every line, with or without a trailing newline and with
+// either LF or CRLF endings. The first blank line ends the rules; any
+// further blank lines are skipped.
+func (p *Parser) Parse() error {
+	for p.scanner.Scan() {
+		p.line++
+		line := bytes.TrimSuffix(p.scanner.Bytes(), []byte("\r"))
+		if len(bytes.TrimSpace(line)) == 0 {
+			p.section = InUpdates
+			continue
+		}
+		var err error
+		switch p.section {
+		case InRules:
+			err = p.parseRule(line)
+		case InUpdates:
+			err = p.parseUpdate(line)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := p.scanner.Err(); err != nil {
+		return fmt.Errorf("Parse() line %d: %w", p.line+1, err)
+	}
+	return nil
+}
+
+func (p *Parser) parseRule(b []byte) error {
+	var rule Rule
+	if err := ruleFormat.Decode(b, &rule); err != nil {
+		return p.locate(err)
+	}
+	p.rules = append(p.rules, rule)
+	return nil
+}
+
+func (p *Parser) parseUpdate(b []byte) error {
+	var update Update
+	if err := updateFormat.Decode(b, &update); err != nil {
+		return p.locate(err)
+	}
+	p.updates = append(p.updates, update)
+	return nil
+}
+
+// locate points a syntax error from a single line at that line of the input.
+func (p *Parser) locate(err error) error {
+	var se *internal.SyntaxError
+	if errors.As(err, &se) {
+		se.Line = p.line
+	}
+	return err
+}
+
+type Manual struct {
+	rules   *graph.Graph[int] // an edge a -> b means page a must be printed before page b.
+	updates []Update
+}
+
+type Solver struct{}
+
+func (Solver) Parse(input []byte) (Manual, error) {
+	parser := NewParser(bytes.NewReader(input))
+	e := parser.Parse()
+	if e != nil {
+		return Manual{}, e
+	}
+	return Manual{graph.FromEdges(ruleEdges(parser.rules)), parser.updates}, nil
+}
+
+func (Solver) Part1(manual Manual) (internal.Answer, error) {
+	midCount := 0
+	for _, update := range manual.updates {
+		if inOrder(manual.rules, update.Pages) {
+			midCount += update.Middle()
+		}
+	}
+	return internal.IntAnswer(midCount), nil
+}
+
+func (Solver) Part2(manual Manual) (internal.Answer, error) {
+	midCount := 0
+	for i, update := range manual.updates {
+		if inOrder(manual.rules, update.Pages) {
+			continue
+		}
+		// only a correction needs the rules to settle every pair of pages.
+		sorted, err := manual.Sorted(update.Pages)
+		if err != nil {
+			return "", fmt.Errorf("Part2() update %d: %w", i+1, err)
+		}
+		fixed := Update{sorted}
+		midCount += fixed.Middle()
+	}
+	return internal.IntAnswer(midCount), nil
+}
+
+// inOrder reports whether no page is preceded by one a rule says must come
+// after it.
+func inOrder(rules *graph.Graph[int], pages []int) bool {
+	for idx, page := range pages {
+		for _, before := range pages[:idx] {
+			if rules.HasEdge(page, before) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Compare orders two pages by the rules, for use with slices.SortFunc. Pages
+// no rule relates compare equal.
+func (m Manual) Compare(a, b int) int {
+	switch {
+	case m.rules.HasEdge(a, b):
+		return -1
+	case m.rules.HasEdge(b, a):
+		return 1
+	}
+	return 0
+}
+
+// Consistent checks that the rules form a total order on pages: every pair
+// of pages is related by a rule in exactly one direction and the rules do
+// not loop. Only then does sorting with Compare give the one order the rules
+// allow.
+func (m Manual) Consistent(pages []int) error {
+	after := make([]int, len(pages))
+	for i, a := range pages {
+		for j := i + 1; j < len(pages); j++ {
+			b := pages[j]
+			if a == b {
+				return fmt.Errorf("page %d appears more than once", a)
+			}
+			forward, backward := m.rules.HasEdge(a, b), m.rules.HasEdge(b, a)
+			if forward && backward {
+				return fmt.Errorf("pages %d and %d are ordered both ways", a, b)
+			}
+			if !forward && !backward {
+				return fmt.Errorf("pages %d and %d are not ordered by any rule", a, b)
+			}
+			if forward {
+				after[i]++
+			} else {
+				after[j]++
+			}
+		}
+	}
+	// in a total order of n pages, exactly one page has k pages after it for
+	// every k below n. A repeated count means the rules go round in a cycle.
+	counted := make([]bool, len(pages))
+	for _, n := range after {
+		if counted[n] {
+			_, err := m.rules.Subgraph(pages).TopoSort()
+			return err
+		}
+		counted[n] = true
+	}
+	return nil
+}
+
+// Sorted returns a copy of pages in the order the rules require, leaving
+// pages untouched.
+func (m Manual) Sorted(pages []int) ([]int, error) {
+	if err := m.Consistent(pages); err != nil {
+		return nil, err
+	}
+	sorted := slices.Clone(pages)
+	slices.SortFunc(sorted, m.Compare)
+	return sorted, nil
+}
+
+// ruleEdges yields every rule as an edge from its former to its latter page.
+func ruleEdges(rules []Rule) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for _, r := range rules {
+			if !yield(r.Former, r.Latter) {
+				return
+			}
+		}
+	}
+}
+
+// Violations returns every rule the update breaks, in the order the pages
+// appear: for each page, the rules it breaks with pages printed after it.
+func (m Manual) Violations(pages []int) []Rule {
+	res := make([]Rule, 0, 8)
+	for i, before := range pages {
+		for _, after := range pages[i+1:] {
+			if m.rules.HasEdge(after, before) {
+				res = append(res, Rule{after, before})
+			}
+		}
+	}
+	return res
+}
+
+// Explain writes one line per update saying whether it is in order. For an
+// update that is not, it names the first broken rule, lists every broken
+// rule and shows the corrected order with its middle page.
+func (Solver) Explain(w io.Writer, manual Manual) error {
+	for i, update := range manual.updates {
+		fmt.Fprintf(w, "update %d: %s", i+1, joinPages(update.Pages))
+		broken := manual.Violations(update.Pages)
+		if len(broken) == 0 {
+			fmt.Fprintf(w, " ok (middle %d)\n", update.Middle())
+			continue
+		}
+		first := broken[0]
+		fmt.Fprintf(w, " breaks %s: page %d appeared after page %d\n", first, first.Former, first.Latter)
+		fmt.Fprintf(w, "  violations: %s\n", strings.Join(internal.MapSlice(broken, Rule.String), " "))
+		sorted, err := manual.Sorted(update.Pages)
+		if err != nil {
+			fmt.Fprintf(w, "  cannot be corrected: %v\n", err)
+			continue
+		}
+		fixed := Update{sorted}
+		fmt.Fprintf(w, "  corrected: %s (middle %d)\n", joinPages(sorted), fixed.Middle())
+	}
+	return nil
+}
+
+func joinPages(pages []int) string {
+	return strings.Join(internal.MapSlice(pages, strconv.Itoa), ",")
+}
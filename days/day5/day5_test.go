@@ -0,0 +1,257 @@
+package day5
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jdpolicano/aof-go/internal"
+	"github.com/jdpolicano/aof-go/internal/graph"
+)
+
+const example = `47|53
+97|13
+97|61
+97|47
+75|29
+61|13
+75|53
+29|13
+97|29
+53|29
+61|53
+97|53
+61|29
+47|13
+75|47
+97|75
+47|61
+75|61
+47|29
+75|13
+53|13
+
+75,47,61,53,29
+97,61,53,29,13
+75,29,13
+75,97,47,61,53
+61,13,29
+97,13,75,29,47
+`
+
+func FuzzParse(f *testing.F) {
+	f.Add([]byte(example))
+	f.Add([]byte("1|2\r\n2|3\r\n\r\n1,2,3\r\n3,2\r\n"))
+	f.Add([]byte("1|2\n\n\n2,1"))
+	f.Add([]byte("1|2\n1,2\n"))
+	f.Add([]byte("1|2|3\n\n1\n"))
+	f.Fuzz(func(t *testing.T, input []byte) {
+		manual, err := Solver{}.Parse(input)
+		if err != nil {
+			var se *internal.SyntaxError
+			if !errors.As(err, &se) {
+				t.Fatalf("error without a position: %v", err)
+			}
+			if lines := bytes.Count(input, []byte("\n")) + 1; se.Line < 1 || se.Line > lines || se.Col < 1 {
+				t.Fatalf("error outside the %d line input: %v", lines, err)
+			}
+			return
+		}
+		if bytes.IndexByte(input, '\r') >= 0 {
+			return
+		}
+		// line endings and the final newline must not change the result.
+		for _, variant := range [][]byte{
+			bytes.ReplaceAll(input, []byte("\n"), []byte("\r\n")),
+			bytes.TrimSuffix(input, []byte("\n")),
+			append(slices.Clone(input), '\n'),
+		} {
+			again, err := Solver{}.Parse(variant)
+			if err != nil {
+				t.Fatalf("Parse(%q) = %v, but Parse(%q) worked", variant, err, input)
+			}
+			if !reflect.DeepEqual(manual, again) {
+				t.Fatalf("Parse(%q) differs from Parse(%q)", variant, input)
+			}
+		}
+	})
+}
+
+func TestExample(t *testing.T) {
+	manual, err := Solver{}.Parse([]byte(example))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := (Solver{}).Part1(manual); got != "143" {
+		t.Errorf("Part1() = %s, want 143", got)
+	}
+	if got, _ := (Solver{}).Part2(manual); got != "123" {
+		t.Errorf("Part2() = %s, want 123", got)
+	}
+}
+
+func TestConsistent(t *testing.T) {
+	manual := Manual{rules: graph.FromEdges(ruleEdges([]Rule{
+		{1, 2}, {2, 3}, {1, 3}, // 1, 2, 3 in order
+		{4, 5}, {5, 4}, // both ways
+		{6, 7}, {7, 8}, {8, 6}, // a cycle
+	}))}
+	tests := []struct {
+		name  string
+		pages []int
+		want  string // part of the error, empty for none
+	}{
+		{"total order", []int{3, 1, 2}, ""},
+		{"single page", []int{9}, ""},
+		{"duplicate page", []int{1, 2, 1}, "page 1 appears more than once"},
+		{"unrelated pair", []int{1, 2, 9}, "pages 1 and 9 are not ordered by any rule"},
+		{"both-ways pair", []int{4, 5}, "pages 4 and 5 are ordered both ways"},
+		{"cycle", []int{6, 7, 8}, "graph has a cycle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := manual.Consistent(tt.pages)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("Consistent(%v) = %v", tt.pages, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Consistent(%v) = %v, want an error containing %q", tt.pages, err, tt.want)
+			}
+			var cycle *graph.CycleError[int]
+			if isCycle := errors.As(err, &cycle); isCycle != (tt.name == "cycle") {
+				t.Errorf("Consistent(%v) = %v, a *CycleError: %t", tt.pages, err, isCycle)
+			}
+		})
+	}
+}
+
+func TestUnrelatedPages(t *testing.T) {
+	// page 9 has no rules; that only matters for an update needing correction.
+	manual, err := Solver{}.Parse([]byte("1|2\n2|3\n\n1,2,9\n3,2,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := (Solver{}).Part1(manual); err != nil || got != "2" {
+		t.Errorf("Part1() = %s, %v, want 2", got, err)
+	}
+	if got, err := (Solver{}).Part2(manual); err == nil {
+		t.Errorf("Part2() = %s, want an error for pages 3 and 1", got)
+	}
+	manual.updates = append(manual.updates[:1], Update{[]int{2, 1, 9}})
+	if got, err := (Solver{}).Part2(manual); err == nil || !strings.Contains(err.Error(), "update 2") {
+		t.Errorf("Part2() = %s, %v, want an error for update 2", got, err)
+	}
+}
+
+// synthetic builds a manual whose rules totally order the pages 0..pages-1,
+// and count updates of size random pages each.
+func synthetic(pages, size, count int) Manual {
+	rng := rand.New(rand.NewPCG(5, 5))
+	order := rng.Perm(pages)
+	rules := make([]Rule, 0, pages*(pages-1)/2)
+	for i, a := range order {
+		for _, b := range order[i+1:] {
+			rules = append(rules, Rule{a, b})
+		}
+	}
+	rng.Shuffle(len(rules), func(i, j int) { rules[i], rules[j] = rules[j], rules[i] })
+	updates := make([]Update, count)
+	for i := range updates {
+		updates[i] = Update{rng.Perm(pages)[:size]}
+	}
+	return Manual{graph.FromEdges(ruleEdges(rules)), updates}
+}
+
+// swapRepair is the repair day5 used before the comparator: walk back from
+// the end, swapping any page with an earlier one that must follow it.
+func swapRepair(rules *graph.Graph[int], update []int) {
+	head := len(update) - 1
+	for head > 0 {
+		wrongIdx := -1
+		for i, n := range update[:head] {
+			if rules.HasEdge(update[head], n) {
+				wrongIdx = i
+				break
+			}
+		}
+		if wrongIdx >= 0 {
+			update[head], update[wrongIdx] = update[wrongIdx], update[head]
+		} else {
+			head--
+		}
+	}
+}
+
+func BenchmarkOrder(b *testing.B) {
+	for _, size := range []int{23, 100, 250} {
+		manual := synthetic(300, size, 50)
+		want := make([][]int, len(manual.updates))
+		for i, u := range manual.updates {
+			sorted, err := manual.Sorted(u.Pages)
+			if err != nil {
+				b.Fatal(err)
+			}
+			want[i] = sorted
+		}
+
+		b.Run(fmt.Sprintf("size=%d/comparator", size), func(b *testing.B) {
+			for range b.N {
+				for _, u := range manual.updates {
+					if _, err := manual.Sorted(u.Pages); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("size=%d/toposort", size), func(b *testing.B) {
+			for range b.N {
+				for i, u := range manual.updates {
+					got, err := manual.rules.Subgraph(u.Pages).TopoSort()
+					if err != nil || (b.N == 1 && !slices.Equal(got, want[i])) {
+						b.Fatalf("update %d: got %v, %v", i, got, err)
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("size=%d/swaprepair", size), func(b *testing.B) {
+			for range b.N {
+				for i, u := range manual.updates {
+					got := slices.Clone(u.Pages)
+					swapRepair(manual.rules, got)
+					if b.N == 1 && !slices.Equal(got, want[i]) {
+						b.Fatalf("update %d: got %v", i, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	manual, err := Solver{}.Parse([]byte(example))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := (Solver{}).Explain(&out, manual); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"update 1: 75,47,61,53,29 ok (middle 61)\n",
+		"update 4: 75,97,47,61,53 breaks 97|75: page 97 appeared after page 75\n",
+		"  violations: 75|13 29|13 47|13 47|29\n",
+		"  corrected: 97,75,47,29,13 (middle 47)\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Explain() output is missing %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package day6
+
+import (
+	"fmt"
+
+	"github.com/jdpolicano/aof-go/internal"
+	"github.com/jdpolicano/aof-go/internal/direction"
+)
+
+func init() {
+	internal.Register(6, Solver{})
+}
+
+const (
+	OutBounds = iota
+	Obstacle  = '#'
+	Empty     = '.'
+	Visited   = 'X'
+	Guard     = '^'
+)
+
+// slot indexes the per-cell jump targets, which only exist for the four
+// cardinal directions.
+func slot(d direction.Direction) int {
+	return int(d) / 2
+}
+
+func at(grid *internal.Grid[byte], c internal.Location) byte {
+	if b, ok := grid.Get(c); ok {
+		return b
+	}
+	return OutBounds
+}
+
+type ChangeRecord struct {
+	pos    internal.Location
+	origin internal.Location
+	dir    direction.Direction
+}
+
+// JumpTable holds, for every cell and direction, where the guard stops when
+// walking that way: the cell before the next obstacle or the first cell
+// outside the grid.
+type JumpTable struct {
+	cells *internal.Grid[[4]internal.Location]
+}
+
+func BuildJumpTable(grid *internal.Grid[byte]) JumpTable {
+	jmp := JumpTable{internal.NewGrid[[4]internal.Location](grid.Width(), grid.Height())}
+	visited := internal.NewGrid[[4]bool](grid.Width(), grid.Height())
+	for i := range grid.Height() {
+		for j := range grid.Width() {
+			co := internal.Location{i, j}
+			jmp.UpdateDirection(grid, co, direction.N, visited)
+			jmp.UpdateDirection(grid, co, direction.E, visited)
+			jmp.UpdateDirection(grid, co, direction.S, visited)
+			jmp.UpdateDirection(grid, co, direction.W, visited)
+		}
+	}
+	return jmp
+}
+
+func (jmp JumpTable) UpdateDirection(data *internal.Grid[byte], start internal.Location, dir direction.Direction, visited *internal.Grid[[4]bool]) internal.Location {
+	seen, cell := visited.Ptr(start), jmp.cells.Ptr(start)
+	if seen[slot(dir)] {
+		return cell[slot(dir)]
+	}
+	seen[slot(dir)] = true
+	next := dir.Step(start)
+	switch {
+	case !data.InBounds(next):
+		cell[slot(dir)] = next
+	case at(data, next) == Obstacle:
+		cell[slot(dir)] = start
+	default:
+		cell[slot(dir)] = jmp.UpdateDirection(data, next, dir, visited)
+	}
+	return cell[slot(dir)]
+}
+
+func (jmp JumpTable) AddObstacle(grid *internal.Grid[byte], center internal.Location) []ChangeRecord {
+	changeSet := make([]ChangeRecord, 0, 256)
+	// We're going to pretend grid[center] = Obstacle here,
+	// but we don't need to modify grid itself if we update jmp.
+	for _, dir := range direction.Cardinal {
+		neighbor := dir.Step(center)
+		if !grid.InBounds(neighbor) || at(grid, neighbor) == Obstacle {
+			// nothing to update in this direction
+			continue
+		}
+		opposite := dir.Opposite()
+		// walk out from the neighbor until you hit an existing obstacle or boundary
+		for c := neighbor; grid.InBounds(c) && at(grid, c) != Obstacle; c = dir.Step(c) {
+			cell := jmp.cells.Ptr(c)
+			// record the old jump-target so we can restore later
+			old := cell[slot(opposite)]
+			// patch it to point at 'neighbor' (the cell just before our new obstacle)
+			cell[slot(opposite)] = neighbor
+			changeSet = append(changeSet, ChangeRecord{pos: c, origin: old, dir: opposite})
+		}
+	}
+
+	return changeSet
+}
+
+func (jmp JumpTable) Restore(c []ChangeRecord) {
+	for _, change := range c {
+		jmp.Set(change.pos, change.origin, change.dir)
+	}
+}
+
+func (jmp JumpTable) Set(pos, val internal.Location, dir direction.Direction) internal.Location {
+	cell := jmp.cells.Ptr(pos)
+	if cell == nil {
+		return internal.Location{}
+	}
+	origin := cell[slot(dir)]
+	cell[slot(dir)] = val
+	return origin
+}
+
+func (jmp JumpTable) Get(start internal.Location, dir direction.Direction) internal.Location {
+	cell := jmp.cells.Ptr(start)
+	if cell == nil {
+		return start
+	}
+	return cell[slot(dir)]
+}
+
+func (jmp JumpTable) PathFrom(start internal.Location, dir direction.Direction) (internal.Location, []internal.Location) {
+	end := jmp.Get(start, dir)
+	path := make([]internal.Location, 0, start.Manhattan(end))
+	for end != start && jmp.cells.InBounds(start) {
+		path = append(path, start)
+		start = dir.Step(start)
+	}
+	return end, path
+}
+
+type Simulator struct {
+	pos  internal.Location
+	grid *internal.Grid[byte]
+	jmp  JumpTable
+	path []internal.Location
+}
+
+func NewSimulator(grid *internal.Grid[byte]) *Simulator {
+	jmp := BuildJumpTable(grid)
+	pos := getStartPos(grid)
+	path := make([]internal.Location, 0, 8192)
+	return &Simulator{pos, grid, jmp, path}
+}
+
+func (sim *Simulator) RunFullUnsafe() {
+	start := sim.pos
+	dir := direction.N // this is always the default
+	for sim.grid.InBounds(start) {
+		next, path := sim.jmp.PathFrom(start, dir)
+		sim.path = append(sim.path, path...)
+		start = next
+		dir = dir.Right()
+	}
+	return
+}
+
+func (sim *Simulator) RunFastUnsafe() {
+	start := sim.pos
+	sim.path = append(sim.path, start)
+	dir := direction.N // this is always the default
+	for sim.grid.InBounds(start) {
+		next := sim.jmp.Get(start, dir)
+		start = next
+		dir = dir.Right()
+		sim.path = append(sim.path, start)
+	}
+	return
+}
+
+// UniquePath returns the cells of the guard's path in the order they were first visited.
+func (sim *Simulator) UniquePath() []internal.Location {
+	seen := internal.NewSet[internal.Location]()
+	unique := make([]internal.Location, 0, 1024)
+	for _, co := range sim.path {
+		if seen.Add(co) {
+			unique = append(unique, co)
+		}
+	}
+	return unique
+}
+
+func (sim *Simulator) CountPossibleCyclesNaive() int {
+	cnt := 0
+	for i := range sim.grid.Height() {
+		for j := range sim.grid.Width() {
+			co := internal.Location{i, j}
+			if co == sim.pos || at(sim.grid, co) == Obstacle {
+				continue
+			}
+			changeSet := sim.jmp.AddObstacle(sim.grid, co)
+			if !sim.Escapes() {
+				cnt++
+			}
+			sim.jmp.Restore(changeSet)
+		}
+	}
+
+	return cnt
+}
+
+func (sim *Simulator) CountPossibleCyclesFast(options []internal.Location) int {
+	cnt := 0
+	for _, co := range options {
+		if co == sim.pos || at(sim.grid, co) == Obstacle {
+			continue
+		}
+		changeSet := sim.jmp.AddObstacle(sim.grid, co)
+		if !sim.Escapes() {
+			cnt++
+		}
+		sim.jmp.Restore(changeSet)
+	}
+	return cnt
+}
+
+func (sim *Simulator) Escapes() bool {
+	slowDir, fastDir := direction.N, direction.N // this is always the default
+	slow, fast := sim.pos, sim.pos
+	for sim.grid.InBounds(fast) {
+		slow = sim.jmp.Get(slow, slowDir)
+		slowDir = slowDir.Right()
+		fast = sim.jmp.Get(fast, fastDir)
+		fastDir = fastDir.Right()
+		fast = sim.jmp.Get(fast, fastDir)
+		fastDir = fastDir.Right()
+		if slow == fast && slowDir == fastDir {
+			return false
+		}
+	}
+	return true
+}
+
+func getStartPos(grid *internal.Grid[byte]) internal.Location {
+	if loc, ok := internal.Find(grid, Guard); ok {
+		return loc
+	}
+	panic("getStartPos() No guard found")
+}
+
+type Solver struct{}
+
+func (Solver) Parse(input []byte) (*internal.Grid[byte], error) {
+	grid, err := internal.ParseGrid(input)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := internal.Find(grid, Guard); !ok {
+		return nil, fmt.Errorf("Parse() no guard found")
+	}
+	return grid, nil
+}
+
+func (Solver) Part1(grid *internal.Grid[byte]) (internal.Answer, error) {
+	sim := NewSimulator(grid)
+	sim.RunFullUnsafe()
+	return internal.IntAnswer(len(sim.UniquePath())), nil
+}
+
+func (Solver) Part2(grid *internal.Grid[byte]) (internal.Answer, error) {
+	sim := NewSimulator(grid)
+	sim.RunFullUnsafe()
+	return internal.IntAnswer(sim.CountPossibleCyclesFast(sim.UniquePath())), nil
+}
@@ -0,0 +1,90 @@
+package day7
+
+import (
+	"github.com/jdpolicano/aof-go/internal"
+)
+
+func init() {
+	internal.Register(7, Solver{})
+}
+
+// Antinodes returns the two points in line with n and other that are twice
+// as far from one of them as from the other.
+func Antinodes(n, other internal.Location) [2]internal.Location {
+	diff := n.Sub(other)
+	return [2]internal.Location{n.Add(diff), other.Sub(diff)}
+}
+
+// AllAntinodes returns every in-bounds point on the line through n and other
+// at a whole multiple of their distance, excluding the two nodes themselves.
+func AllAntinodes(n, other internal.Location, inBounds func(internal.Location) bool) []internal.Location {
+	antis := make([]internal.Location, 0, 32)
+	diff := n.Sub(other)
+	// first calulate all of the antis going "up"
+	for curr := n.Add(diff); inBounds(curr); curr = curr.Add(diff) {
+		antis = append(antis, curr)
+	}
+
+	// then calulate all of the antis going "down"
+	for curr := other.Sub(diff); inBounds(curr); curr = curr.Sub(diff) {
+		antis = append(antis, curr)
+	}
+	return antis
+}
+
+// antennas returns every non-empty cell in row-major order.
+func antennas(grid *internal.Grid[byte]) []internal.Location {
+	return grid.FindAllFunc(func(b byte) bool { return b != '.' })
+}
+
+type Solver struct{}
+
+func (Solver) Parse(input []byte) (*internal.Grid[byte], error) {
+	return internal.ParseGrid(input)
+}
+
+func (Solver) Part1(grid *internal.Grid[byte]) (internal.Answer, error) {
+	nodes := internal.NewMultiMap[byte, internal.Location]()
+	antinodes := internal.NewSet[internal.Location]()
+	for _, n := range antennas(grid) {
+		freq, _ := grid.Get(n)
+		for _, loc := range nodes.Get(freq) {
+			for _, l := range Antinodes(n, loc) {
+				if grid.InBounds(l) {
+					antinodes.Add(l)
+				}
+			}
+		}
+		nodes.Add(freq, n)
+	}
+	return internal.IntAnswer(antinodes.Len()), nil
+}
+
+func (Solver) Part2(grid *internal.Grid[byte]) (internal.Answer, error) {
+	nodes := internal.NewMultiMap[byte, internal.Location]()
+	antinodes := internal.NewSet[internal.Location]()
+	for _, n := range antennas(grid) {
+		freq, _ := grid.Get(n)
+		// now, check the lines between this node and the others of the same type we have passed.
+		if prev := nodes.Get(freq); len(prev) > 0 {
+			// for each previous node...
+			for _, loc := range prev {
+				// get the two nodes that are colinear
+				antis := AllAntinodes(n, loc, grid.InBounds)
+				// count every one of them as an antinode
+				for _, l := range antis {
+					antinodes.Add(l)
+				}
+			}
+		}
+		nodes.Add(freq, n)
+	}
+	for _, occurances := range nodes.All() {
+		if len(occurances) > 2 {
+			for _, l := range occurances {
+				antinodes.Add(l)
+			}
+		}
+	}
+	return internal.IntAnswer(antinodes.Len()), nil
+}
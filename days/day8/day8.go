@@ -1,11 +1,15 @@
-package main
+package day8
 
 import (
 	"bytes"
-	"log"
-	"os"
+
+	"github.com/jdpolicano/aof-go/internal"
 )
 
+func init() {
+	internal.Register(8, Solver{})
+}
+
 type Slicer[T any] interface {
 	Slice(size int) T
 }
@@ -18,12 +22,17 @@ type Block struct {
 	prev *Block
 }
 
-func main() {
-	fs, err := os.ReadFile("./cmd/day8/test_input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	fs = bytes.Trim(fs, "\n\r\t ")
+type Solver struct{}
+
+func (Solver) Parse(input []byte) ([]byte, error) {
+	return bytes.Trim(input, "\n\r\t "), nil
+}
+
+func (Solver) Part1(fs []byte) (internal.Answer, error) {
+	return "", internal.ErrNotImplemented
+}
+
+func (Solver) Part2(fs []byte) (internal.Answer, error) {
 	// left, right := 0, len(blocks)-1
 	// for left < right {
 	// 	b1, b2 := &blocks[left], &blocks[right]
@@ -58,4 +67,5 @@ func main() {
 
 	// fmt.Println("final layout", final)
 	// fmt.Println("checksum", checksum)
+	return "", internal.ErrNotImplemented
 }
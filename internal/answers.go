@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// AnswersFile is the name of the known-answer store in the module root.
+const AnswersFile = "answers.json"
+
+// HashInput identifies a puzzle input by the SHA-256 of its content, so
+// answers for different people's inputs can live side by side.
+func HashInput(input []byte) string {
+	sum := sha256.Sum256(input)
+	return hex.EncodeToString(sum[:])
+}
+
+// KnownAnswer is an accepted answer for one part of a day on one input.
+type KnownAnswer struct {
+	Day    int    `json:"day"`
+	Part   int    `json:"part"`
+	Input  string `json:"input"`
+	Answer Answer `json:"answer"`
+}
+
+type answerKey struct {
+	day   int
+	part  int
+	input string
+}
+
+// AnswerStore holds known answers keyed by day, part and input hash.
+type AnswerStore struct {
+	path    string
+	answers map[answerKey]Answer
+}
+
+// DefaultAnswersPath returns the location of the store in the module root.
+func DefaultAnswersPath() (string, error) {
+	root, err := Root()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, AnswersFile), nil
+}
+
+// OpenAnswers loads the store at path. A missing file is an empty store.
+func OpenAnswers(path string) (*AnswerStore, error) {
+	s := &AnswerStore{path, make(map[answerKey]Answer)}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var known []KnownAnswer
+	if err := json.Unmarshal(b, &known); err != nil {
+		return nil, err
+	}
+	for _, k := range known {
+		s.answers[answerKey{k.Day, k.Part, k.Input}] = k.Answer
+	}
+	return s, nil
+}
+
+func (s *AnswerStore) Lookup(day, part int, input string) (Answer, bool) {
+	a, ok := s.answers[answerKey{day, part, input}]
+	return a, ok
+}
+
+// Record stores an accepted answer, replacing any previous one, and reports
+// whether the store changed.
+func (s *AnswerStore) Record(day, part int, input string, a Answer) bool {
+	key := answerKey{day, part, input}
+	if old, ok := s.answers[key]; ok && old == a {
+		return false
+	}
+	s.answers[key] = a
+	return true
+}
+
+// Save writes the store back to disk sorted by day, part and input so the
+// file diffs cleanly.
+func (s *AnswerStore) Save() error {
+	known := make([]KnownAnswer, 0, len(s.answers))
+	for k, a := range s.answers {
+		known = append(known, KnownAnswer{k.day, k.part, k.input, a})
+	}
+	slices.SortFunc(known, func(a, b KnownAnswer) int {
+		return cmp.Or(cmp.Compare(a.Day, b.Day), cmp.Compare(a.Part, b.Part), cmp.Compare(a.Input, b.Input))
+	})
+	b, err := json.MarshalIndent(known, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, append(b, '\n'), 0o644)
+}
@@ -0,0 +1,281 @@
+// Package client talks to the Advent of Code website: it downloads puzzle
+// inputs into a local cache and submits answers, while staying within a
+// polite request rate.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBaseURL   = "https://adventofcode.com"
+	DefaultUserAgent = "github.com/jdpolicano/aof-go"
+	// DefaultInterval is the minimum time between two requests to the site.
+	DefaultInterval = 5 * time.Second
+	// SessionEnv holds the value of the site's session cookie.
+	SessionEnv = "AOC_SESSION"
+	// LastRequestFile is the name of the file inside the cache directory
+	// recording when the last request was sent, so that Interval holds
+	// across separate runs of the program.
+	LastRequestFile = "last-request"
+)
+
+var (
+	ErrNoSession      = errors.New("no session token, set $" + SessionEnv + " or write it to the session config file")
+	ErrSessionExpired = errors.New("session token is invalid or expired")
+	ErrNotReleased    = errors.New("puzzle is not released yet")
+)
+
+// release is the time zone puzzles unlock in, at midnight.
+var release = time.FixedZone("EST", -5*60*60)
+
+// ReleaseTime returns when the given puzzle unlocks.
+func ReleaseTime(year, day int) time.Time {
+	return time.Date(year, time.December, day, 0, 0, 0, 0, release)
+}
+
+// SessionPath is the config file read when $AOC_SESSION is unset.
+func SessionPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aoc", "session"), nil
+}
+
+// LoadSession returns the session token from the environment or the config file.
+func LoadSession() (string, error) {
+	if s := strings.TrimSpace(os.Getenv(SessionEnv)); s != "" {
+		return s, nil
+	}
+	path, err := SessionPath()
+	if err != nil {
+		return "", err
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNoSession
+	}
+	if err != nil {
+		return "", err
+	}
+	if s := strings.TrimSpace(string(b)); s != "" {
+		return s, nil
+	}
+	return "", ErrNoSession
+}
+
+// Client fetches puzzle data. The zero value is not usable, use New.
+type Client struct {
+	BaseURL   string
+	Session   string
+	UserAgent string
+	// CacheDir holds downloaded inputs as <year>/day<N>.txt. Caching is
+	// disabled when it is empty.
+	CacheDir string
+	Interval time.Duration
+	HTTP     *http.Client
+	Now      func() time.Time
+	// History, when set, guards and records submissions.
+	History *History
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// New returns a client for the live site caching inputs in the user's cache directory.
+func New(session string) *Client {
+	cache := ""
+	if dir, err := os.UserCacheDir(); err == nil {
+		cache = filepath.Join(dir, "aoc")
+	}
+	return &Client{
+		BaseURL:   DefaultBaseURL,
+		Session:   session,
+		UserAgent: DefaultUserAgent,
+		CacheDir:  cache,
+		Interval:  DefaultInterval,
+		HTTP: &http.Client{
+			Timeout: 30 * time.Second,
+			// the site redirects anonymous users instead of failing, which
+			// would otherwise hide an expired session behind a 200.
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		Now: time.Now,
+	}
+}
+
+// Input returns the puzzle input for the day, from the cache when possible.
+func (c *Client) Input(ctx context.Context, year, day int) ([]byte, error) {
+	path := c.cachePath(year, day)
+	if path != "" {
+		if b, err := os.ReadFile(path); err == nil {
+			return b, nil
+		}
+	}
+	if err := c.checkReleased(year, day); err != nil {
+		return nil, err
+	}
+	b, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", year, day), nil)
+	if err != nil {
+		return nil, fmt.Errorf("input for %d day %d: %w", year, day, err)
+	}
+	if path != "" {
+		if err := writeFileAtomic(path, b); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func (c *Client) cachePath(year, day int) string {
+	if c.CacheDir == "" {
+		return ""
+	}
+	return filepath.Join(c.CacheDir, strconv.Itoa(year), "day"+strconv.Itoa(day)+".txt")
+}
+
+func (c *Client) checkReleased(year, day int) error {
+	if day < 1 || day > 25 {
+		return fmt.Errorf("day %d is not between 1 and 25", day)
+	}
+	if at := ReleaseTime(year, day); c.now().Before(at) {
+		return fmt.Errorf("%w: %d day %d unlocks at %s", ErrNotReleased, year, day, at.Local().Format(time.RFC1123))
+	}
+	return nil
+}
+
+func (c *Client) now() time.Time {
+	if c.Now == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// wait blocks until another request may be sent without exceeding Interval,
+// counting requests made by earlier runs that shared the cache directory.
+func (c *Client) wait(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	last := c.last
+	if stored := c.loadLast(); stored.After(last) {
+		last = stored
+	}
+	if !last.IsZero() {
+		if d := c.Interval - time.Since(last); d > 0 {
+			t := time.NewTimer(d)
+			defer t.Stop()
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-t.C:
+			}
+		}
+	}
+	c.last = time.Now()
+	return c.saveLast()
+}
+
+func (c *Client) lastPath() string {
+	if c.CacheDir == "" {
+		return ""
+	}
+	return filepath.Join(c.CacheDir, LastRequestFile)
+}
+
+// loadLast reads the time of the last request recorded in the cache, the
+// zero time if there is none or it cannot be read.
+func (c *Client) loadLast() time.Time {
+	path := c.lastPath()
+	if path == "" {
+		return time.Time{}
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(b)))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func (c *Client) saveLast() error {
+	path := c.lastPath()
+	if path == "" {
+		return nil
+	}
+	return writeFileAtomic(path, []byte(c.last.Format(time.RFC3339Nano)+"\n"))
+}
+
+// do sends an authenticated request and returns the body of a 200 response.
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader) ([]byte, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(c.BaseURL, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.UserAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return b, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotReleased
+	case resp.StatusCode == http.StatusBadRequest,
+		resp.StatusCode == http.StatusUnauthorized,
+		resp.StatusCode == http.StatusForbidden,
+		resp.StatusCode >= 300 && resp.StatusCode < 400:
+		return nil, ErrSessionExpired
+	default:
+		return nil, fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+}
+
+func writeFileAtomic(path string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
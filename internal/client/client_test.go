@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeSite stands in for the website, serving inputs to the "good" session.
+func fakeSite(t *testing.T, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /2024/day/{day}/input", func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.UserAgent() != DefaultUserAgent {
+			t.Errorf("User-Agent = %q", r.UserAgent())
+		}
+		if c, err := r.Cookie("session"); err != nil || c.Value != "good" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		if r.PathValue("day") == "25" {
+			http.Error(w, "Please don't repeatedly request this endpoint before it unlocks!", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("input for day " + r.PathValue("day") + "\n"))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestClient(t *testing.T, srv *httptest.Server, session string) *Client {
+	c := New(session)
+	c.BaseURL = srv.URL
+	c.CacheDir = t.TempDir()
+	c.Interval = 0
+	c.Now = func() time.Time { return time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC) }
+	return c
+}
+
+func TestInputCaches(t *testing.T) {
+	var hits atomic.Int32
+	c := newTestClient(t, fakeSite(t, &hits), "good")
+
+	for range 2 {
+		b, err := c.Input(context.Background(), 2024, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != "input for day 3\n" {
+			t.Fatalf("Input() = %q", b)
+		}
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("server hit %d times, want 1", n)
+	}
+	if _, err := os.Stat(filepath.Join(c.CacheDir, "2024", "day3.txt")); err != nil {
+		t.Errorf("input not cached: %v", err)
+	}
+}
+
+func TestInputErrors(t *testing.T) {
+	var hits atomic.Int32
+	srv := fakeSite(t, &hits)
+
+	tests := []struct {
+		name    string
+		session string
+		day     int
+		now     time.Time
+		want    error
+	}{
+		{"expired session", "stale", 1, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), ErrSessionExpired},
+		{"no session", "", 1, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), ErrNoSession},
+		{"not released on server", "good", 25, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), ErrNotReleased},
+		{"not released locally", "good", 10, time.Date(2024, 12, 10, 4, 59, 0, 0, time.UTC), ErrNotReleased},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, srv, tt.session)
+			c.Now = func() time.Time { return tt.now }
+			_, err := c.Input(context.Background(), 2024, tt.day)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Input() err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	var hits atomic.Int32
+	c := newTestClient(t, fakeSite(t, &hits), "good")
+	c.CacheDir = ""
+	c.Interval = 50 * time.Millisecond
+
+	begin := time.Now()
+	for range 3 {
+		if _, err := c.Input(context.Background(), 2024, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(begin); elapsed < 2*c.Interval {
+		t.Errorf("3 requests took %s, want at least %s", elapsed, 2*c.Interval)
+	}
+}
+
+func TestRateLimitAcrossClients(t *testing.T) {
+	var hits atomic.Int32
+	srv := fakeSite(t, &hits)
+	first := newTestClient(t, srv, "good")
+	first.Interval = 50 * time.Millisecond
+	// a second client sharing the cache stands in for a later run.
+	second := newTestClient(t, srv, "good")
+	second.CacheDir = first.CacheDir
+	second.Interval = first.Interval
+
+	begin := time.Now()
+	if _, err := first.Input(context.Background(), 2024, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := second.Input(context.Background(), 2024, 2); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(begin); elapsed < first.Interval {
+		t.Errorf("2 requests took %s, want at least %s", elapsed, first.Interval)
+	}
+	if _, err := os.Stat(filepath.Join(first.CacheDir, LastRequestFile)); err != nil {
+		t.Errorf("last request not recorded: %v", err)
+	}
+}
+
+func TestReleaseTime(t *testing.T) {
+	got := ReleaseTime(2024, 1).UTC()
+	want := time.Date(2024, time.December, 1, 5, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("ReleaseTime() = %s, want %s", got, want)
+	}
+}
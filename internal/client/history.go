@@ -0,0 +1,109 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HistoryFile is the name of the attempt history inside the cache directory.
+const HistoryFile = "submissions.json"
+
+var (
+	ErrAlreadyCorrect = errors.New("part already solved")
+	ErrKnownWrong     = errors.New("answer was already rejected")
+	ErrOutOfBounds    = errors.New("answer is outside the bounds of earlier attempts")
+	ErrTooSoon        = errors.New("still waiting out the previous attempt")
+)
+
+// Attempt is one submitted answer and the site's verdict.
+type Attempt struct {
+	Year    int           `json:"year"`
+	Day     int           `json:"day"`
+	Part    int           `json:"part"`
+	Answer  string        `json:"answer"`
+	Outcome Outcome       `json:"outcome"`
+	At      time.Time     `json:"at"`
+	Wait    time.Duration `json:"wait,omitempty"`
+}
+
+// History remembers past attempts so that answers the site has already
+// rejected, directly or through a too high / too low bound, are never sent
+// again.
+type History struct {
+	path     string
+	Attempts []Attempt
+}
+
+// LoadHistory reads the history at path. A missing file is an empty history.
+func LoadHistory(path string) (*History, error) {
+	h := &History{path: path}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &h.Attempts); err != nil {
+		return nil, fmt.Errorf("LoadHistory() %s: %w", path, err)
+	}
+	return h, nil
+}
+
+func (h *History) Add(a Attempt) {
+	h.Attempts = append(h.Attempts, a)
+}
+
+func (h *History) Save() error {
+	b, err := json.MarshalIndent(h.Attempts, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(h.path, append(b, '\n'))
+}
+
+// Check reports why answer should not be submitted, or nil if it may be.
+func (h *History) Check(year, day, part int, answer string, now time.Time) error {
+	value, numeric := new(big.Int).SetString(answer, 10)
+	for _, a := range h.Attempts {
+		if a.Year != year || a.Day != day || a.Part != part {
+			continue
+		}
+		if until := a.At.Add(a.Wait); now.Before(until) {
+			return fmt.Errorf("%w: try again in %s", ErrTooSoon, until.Sub(now).Round(time.Second))
+		}
+		switch a.Outcome {
+		case Correct, AlreadySolved:
+			return ErrAlreadyCorrect
+		case Wrong, TooHigh, TooLow:
+			if a.Answer == answer {
+				return fmt.Errorf("%w: %s was %s", ErrKnownWrong, answer, a.Outcome)
+			}
+		}
+		if !numeric {
+			continue
+		}
+		bound, ok := new(big.Int).SetString(a.Answer, 10)
+		if !ok {
+			continue
+		}
+		if a.Outcome == TooHigh && value.Cmp(bound) >= 0 {
+			return fmt.Errorf("%w: %s was too high", ErrOutOfBounds, a.Answer)
+		}
+		if a.Outcome == TooLow && value.Cmp(bound) <= 0 {
+			return fmt.Errorf("%w: %s was too low", ErrOutOfBounds, a.Answer)
+		}
+	}
+	return nil
+}
+
+// DefaultHistoryPath is where a client created by New keeps its history.
+func (c *Client) DefaultHistoryPath() string {
+	return filepath.Join(c.CacheDir, HistoryFile)
+}
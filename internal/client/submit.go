@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outcome is the site's verdict on a submitted answer.
+type Outcome int
+
+const (
+	Unknown Outcome = iota
+	Correct
+	Wrong
+	TooHigh
+	TooLow
+	Wait          // submitted too soon after a previous attempt
+	AlreadySolved // the part was already completed
+)
+
+var outcomeNames = [...]string{
+	Unknown:       "unknown",
+	Correct:       "correct",
+	Wrong:         "wrong",
+	TooHigh:       "too high",
+	TooLow:        "too low",
+	Wait:          "wait",
+	AlreadySolved: "already solved",
+}
+
+func (o Outcome) String() string {
+	if o < 0 || int(o) >= len(outcomeNames) {
+		return "Outcome(" + strconv.Itoa(int(o)) + ")"
+	}
+	return outcomeNames[o]
+}
+
+func (o Outcome) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+func (o *Outcome) UnmarshalText(b []byte) error {
+	for i, name := range outcomeNames {
+		if name == string(b) {
+			*o = Outcome(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown outcome %q", b)
+}
+
+// Verdict is a parsed answer response.
+type Verdict struct {
+	Outcome Outcome
+	// Wait is how long the site asks to hold off before the next attempt.
+	Wait    time.Duration
+	Message string
+}
+
+var (
+	articleRe  = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRe      = regexp.MustCompile(`<[^>]*>`)
+	spaceRe    = regexp.MustCompile(`\s+`)
+	leftRe     = regexp.MustCompile(`(?:(\d+)m )?(\d+)s left to wait`)
+	waitMinsRe = regexp.MustCompile(`wait (one|\d+) minutes? before trying again`)
+)
+
+// ParseVerdict reads the outcome out of the page returned for a submission.
+func ParseVerdict(page []byte) Verdict {
+	text := string(page)
+	if m := articleRe.FindStringSubmatch(text); m != nil {
+		text = m[1]
+	}
+	text = tagRe.ReplaceAllString(text, "")
+	text = strings.TrimSpace(spaceRe.ReplaceAllString(text, " "))
+	v := Verdict{Message: text}
+
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		v.Outcome = Correct
+	case strings.Contains(text, "You gave an answer too recently"):
+		v.Outcome = Wait
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		v.Outcome = AlreadySolved
+	case strings.Contains(text, "your answer is too high"):
+		v.Outcome = TooHigh
+	case strings.Contains(text, "your answer is too low"):
+		v.Outcome = TooLow
+	case strings.Contains(text, "That's not the right answer"):
+		v.Outcome = Wrong
+	}
+
+	if m := leftRe.FindStringSubmatch(text); m != nil {
+		mins, _ := strconv.Atoi(m[1])
+		secs, _ := strconv.Atoi(m[2])
+		v.Wait = time.Duration(mins)*time.Minute + time.Duration(secs)*time.Second
+	} else if m := waitMinsRe.FindStringSubmatch(text); m != nil {
+		mins := 1
+		if m[1] != "one" {
+			mins, _ = strconv.Atoi(m[1])
+		}
+		v.Wait = time.Duration(mins) * time.Minute
+	}
+	return v
+}
+
+// Submit posts an answer for one part. When the client has a History the
+// answer is first checked against previous attempts, and the verdict is
+// recorded and saved afterwards.
+func (c *Client) Submit(ctx context.Context, year, day, part int, answer string) (Verdict, error) {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return Verdict{}, fmt.Errorf("empty answer")
+	}
+	if part != 1 && part != 2 {
+		return Verdict{}, fmt.Errorf("part must be 1 or 2, got %d", part)
+	}
+	if err := c.checkReleased(year, day); err != nil {
+		return Verdict{}, err
+	}
+	if c.History != nil {
+		if err := c.History.Check(year, day, part, answer, c.now()); err != nil {
+			return Verdict{}, err
+		}
+	}
+
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	page, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return Verdict{}, fmt.Errorf("submit %d day %d part %d: %w", year, day, part, err)
+	}
+	v := ParseVerdict(page)
+	if c.History != nil {
+		c.History.Add(Attempt{
+			Year:    year,
+			Day:     day,
+			Part:    part,
+			Answer:  answer,
+			Outcome: v.Outcome,
+			At:      c.now(),
+			Wait:    v.Wait,
+		})
+		if err := c.History.Save(); err != nil {
+			return v, err
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseVerdict(t *testing.T) {
+	tests := []struct {
+		fixture string
+		outcome Outcome
+		wait    time.Duration
+	}{
+		{"correct.html", Correct, 0},
+		{"too_high.html", TooHigh, time.Minute},
+		{"too_low.html", TooLow, time.Minute},
+		{"wrong.html", Wrong, 5 * time.Minute},
+		{"wait.html", Wait, 4*time.Minute + 36*time.Second},
+		{"already_solved.html", AlreadySolved, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			page, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			v := ParseVerdict(page)
+			if v.Outcome != tt.outcome || v.Wait != tt.wait {
+				t.Errorf("ParseVerdict() = %s after %s, want %s after %s (%q)", v.Outcome, v.Wait, tt.outcome, tt.wait, v.Message)
+			}
+		})
+	}
+}
+
+// answerSite replies to part 1 of 2024 day 1 as if the answer were 42.
+func answerSite(t *testing.T, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /2024/day/1/answer", func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		if r.PostForm.Get("level") != "1" {
+			t.Errorf("level = %q", r.PostForm.Get("level"))
+		}
+		fixture := "wrong.html"
+		switch answer := r.PostForm.Get("answer"); {
+		case answer == "42":
+			fixture = "correct.html"
+		case len(answer) > 2:
+			fixture = "too_high.html"
+		case answer < "42":
+			fixture = "too_low.html"
+		}
+		http.ServeFile(w, r, filepath.Join("testdata", fixture))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSubmitHistory(t *testing.T) {
+	var hits atomic.Int32
+	c := newTestClient(t, answerSite(t, &hits), "good")
+	path := filepath.Join(t.TempDir(), HistoryFile)
+	h, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.History = h
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	c.Now = func() time.Time { return now }
+
+	steps := []struct {
+		answer  string
+		advance time.Duration
+		outcome Outcome
+		err     error
+	}{
+		{"100", 0, TooHigh, nil},
+		{"50", 0, Unknown, ErrTooSoon},
+		{"100", 2 * time.Minute, Unknown, ErrKnownWrong},
+		{"150", 0, Unknown, ErrOutOfBounds},
+		{"10", 0, TooLow, nil},
+		{"5", 2 * time.Minute, Unknown, ErrOutOfBounds},
+		{"42", 0, Correct, nil},
+		{"43", 0, Unknown, ErrAlreadyCorrect},
+	}
+	for _, s := range steps {
+		now = now.Add(s.advance)
+		v, err := c.Submit(context.Background(), 2024, 1, 1, s.answer)
+		if !errors.Is(err, s.err) {
+			t.Fatalf("Submit(%s) err = %v, want %v", s.answer, err, s.err)
+		}
+		if v.Outcome != s.outcome {
+			t.Fatalf("Submit(%s) = %s, want %s", s.answer, v.Outcome, s.outcome)
+		}
+	}
+	if n := hits.Load(); n != 3 {
+		t.Errorf("server hit %d times, want 3", n)
+	}
+
+	saved, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Attempts) != 3 || saved.Attempts[2].Outcome != Correct {
+		t.Errorf("saved history = %+v", saved.Attempts)
+	}
+}
@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"iter"
+	"slices"
+)
+
+// Counter is a multiset: it tracks how many times each key was added.
+// Iteration follows the order keys were first added, so anything derived
+// from a Counter is reproducible from run to run.
+type Counter[K comparable] struct {
+	counts map[K]int
+	order  []K
+	total  int
+}
+
+// Tally is a key and how many times it was counted.
+type Tally[K comparable] struct {
+	Key   K
+	Count int
+}
+
+func NewCounter[K comparable]() *Counter[K] {
+	return &Counter[K]{counts: make(map[K]int)}
+}
+
+// CounterOf counts every value in seq.
+func CounterOf[K comparable](seq iter.Seq[K]) *Counter[K] {
+	c := NewCounter[K]()
+	for k := range seq {
+		c.Add(k)
+	}
+	return c
+}
+
+func (c *Counter[K]) Add(k K) {
+	c.AddN(k, 1)
+}
+
+// AddN counts k n more times. Non-positive n is ignored.
+func (c *Counter[K]) AddN(k K, n int) {
+	if n <= 0 {
+		return
+	}
+	if _, exists := c.counts[k]; !exists {
+		c.order = append(c.order, k)
+	}
+	c.counts[k] += n
+	c.total += n
+}
+
+// Count returns how many times k was added, zero if never.
+func (c *Counter[K]) Count(k K) int {
+	return c.counts[k]
+}
+
+// Len is the number of distinct keys.
+func (c *Counter[K]) Len() int {
+	return len(c.order)
+}
+
+// Total is the sum of all counts.
+func (c *Counter[K]) Total() int {
+	return c.total
+}
+
+// All yields every key and its count in the order keys were first added.
+func (c *Counter[K]) All() iter.Seq2[K, int] {
+	return func(yield func(K, int) bool) {
+		for _, k := range c.order {
+			if !yield(k, c.counts[k]) {
+				return
+			}
+		}
+	}
+}
+
+// Keys yields every distinct key in the order it was first added.
+func (c *Counter[K]) Keys() iter.Seq[K] {
+	return slices.Values(c.order)
+}
+
+// MostCommon returns the n keys with the highest counts, highest first.
+// Ties keep insertion order. A negative n returns every key.
+func (c *Counter[K]) MostCommon(n int) []Tally[K] {
+	res := make([]Tally[K], 0, len(c.order))
+	for k, count := range c.All() {
+		res = append(res, Tally[K]{k, count})
+	}
+	slices.SortStableFunc(res, func(a, b Tally[K]) int { return b.Count - a.Count })
+	if n >= 0 && n < len(res) {
+		res = res[:n]
+	}
+	return res
+}
+
+// Intersect returns the keys present in both counters, each counted the
+// lesser number of times.
+func (c *Counter[K]) Intersect(other *Counter[K]) *Counter[K] {
+	res := NewCounter[K]()
+	for k, count := range c.All() {
+		res.AddN(k, min(count, other.Count(k)))
+	}
+	return res
+}
+
+// Union returns the keys present in either counter, each counted the
+// greater number of times.
+func (c *Counter[K]) Union(other *Counter[K]) *Counter[K] {
+	res := NewCounter[K]()
+	for k, count := range c.All() {
+		res.AddN(k, max(count, other.Count(k)))
+	}
+	for k, count := range other.All() {
+		if c.Count(k) == 0 {
+			res.AddN(k, count)
+		}
+	}
+	return res
+}
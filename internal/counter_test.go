@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func countRunes(s string) *Counter[rune] {
+	return CounterOf(slices.Values([]rune(s)))
+}
+
+func TestMostCommon(t *testing.T) {
+	// b and c tie with a, and a was added first; d and e tie below them.
+	c := countRunes("abcbcade")
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, ""},
+		{1, "a"},
+		{3, "abc"},
+		{4, "abcd"},
+		{5, "abcde"},
+		{9, "abcde"},
+		{-1, "abcde"},
+		{-7, "abcde"},
+	}
+	for _, tt := range tests {
+		var keys strings.Builder
+		for _, tally := range c.MostCommon(tt.n) {
+			keys.WriteRune(tally.Key)
+		}
+		if keys.String() != tt.want {
+			t.Errorf("MostCommon(%d) = %q, want %q", tt.n, keys.String(), tt.want)
+		}
+	}
+	got := countRunes("xyzzy").MostCommon(-1)
+	want := []Tally[rune]{{'y', 2}, {'z', 2}, {'x', 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MostCommon(-1) = %v, want %v", got, want)
+	}
+}
+
+func TestCounterAlgebra(t *testing.T) {
+	a, b := countRunes("aaabbc"), countRunes("abbbbd")
+	tests := []struct {
+		name  string
+		got   *Counter[rune]
+		want  map[rune]int
+		order string
+	}{
+		{"Intersect", a.Intersect(b), map[rune]int{'a': 1, 'b': 2}, "ab"},
+		{"Intersect reversed", b.Intersect(a), map[rune]int{'a': 1, 'b': 2}, "ab"},
+		{"Union", a.Union(b), map[rune]int{'a': 3, 'b': 4, 'c': 1, 'd': 1}, "abcd"},
+		{"Union reversed", b.Union(a), map[rune]int{'a': 3, 'b': 4, 'd': 1, 'c': 1}, "abdc"},
+		{"Intersect empty", a.Intersect(NewCounter[rune]()), map[rune]int{}, ""},
+	}
+	for _, tt := range tests {
+		if got := maps.Collect(tt.got.All()); !maps.Equal(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := string(slices.Collect(tt.got.Keys())); got != tt.order {
+			t.Errorf("%s keys = %q, want %q", tt.name, got, tt.order)
+		}
+		total := 0
+		for _, n := range tt.want {
+			total += n
+		}
+		if tt.got.Total() != total || tt.got.Len() != len(tt.want) {
+			t.Errorf("%s has total %d and %d keys, want %d and %d", tt.name, tt.got.Total(), tt.got.Len(), total, len(tt.want))
+		}
+	}
+}
+
+func TestAddN(t *testing.T) {
+	c := NewCounter[string]()
+	c.AddN("a", 0)
+	c.AddN("b", -2)
+	c.AddN("c", 3)
+	if c.Len() != 1 || c.Total() != 3 || c.Count("a") != 0 || c.Count("c") != 3 {
+		t.Errorf("after AddN counter has %v", maps.Collect(c.All()))
+	}
+}
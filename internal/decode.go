@@ -0,0 +1,314 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Format is a compiled scanf-style record layout such as "%d|%d" or
+// "%d: %d...". Verbs are:
+//
+//	%d    a decimal integer with an optional sign
+//	%s    a run of bytes up to whitespace or the next literal
+//	%%    a literal percent sign
+//
+// A verb followed by "..." repeats, separated by whitespace, and fills a
+// slice. A verb followed by one separator byte and "..." (as in "%d,...")
+// repeats separated by that byte instead. Whitespace in the pattern matches
+// one or more spaces or tabs; every other byte must match exactly.
+type Format struct {
+	pattern string
+	ops     []formatOp
+	verbs   int
+}
+
+type opKind uint8
+
+const (
+	opLiteral opKind = iota
+	opSpace
+	opVerb
+)
+
+type formatOp struct {
+	kind    opKind
+	literal string
+	verb    byte
+	repeat  bool
+	sep     byte // 0 means whitespace
+}
+
+func CompileFormat(pattern string) (*Format, error) {
+	f := &Format{pattern: pattern}
+	lit := make([]byte, 0, len(pattern))
+	flush := func() {
+		if len(lit) > 0 {
+			f.ops = append(f.ops, formatOp{kind: opLiteral, literal: string(lit)})
+			lit = lit[:0]
+		}
+	}
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case isSpace(c):
+			flush()
+			for i+1 < len(pattern) && isSpace(pattern[i+1]) {
+				i++
+			}
+			f.ops = append(f.ops, formatOp{kind: opSpace})
+		case c == '%':
+			if i+1 >= len(pattern) {
+				return nil, fmt.Errorf("CompileFormat() %q ends with a lone %%", pattern)
+			}
+			i++
+			if pattern[i] == '%' {
+				lit = append(lit, '%')
+				continue
+			}
+			if pattern[i] != 'd' && pattern[i] != 's' {
+				return nil, fmt.Errorf("CompileFormat() %q has unknown verb %%%c", pattern, pattern[i])
+			}
+			flush()
+			op := formatOp{kind: opVerb, verb: pattern[i]}
+			if rest := pattern[i+1:]; strings.HasPrefix(rest, "...") {
+				op.repeat = true
+				i += 3
+			} else if len(rest) > 3 && rest[1:4] == "..." && !isSpace(rest[0]) && rest[0] != '%' {
+				op.repeat, op.sep = true, rest[0]
+				i += 4
+			}
+			f.ops = append(f.ops, op)
+			f.verbs++
+		default:
+			lit = append(lit, c)
+		}
+	}
+	flush()
+	return f, nil
+}
+
+// MustFormat is CompileFormat for patterns known to be valid.
+func MustFormat(pattern string) *Format {
+	f, err := CompileFormat(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func (f *Format) String() string {
+	return f.pattern
+}
+
+// Decode matches line against the format and stores one value per verb.
+// Pass either one pointer per verb, or a single pointer to a struct whose
+// exported fields take the values in order. Integer verbs fill any integer
+// kind, %s fills a string, and repeated verbs fill a slice of those. A
+// mismatch is reported as a *SyntaxError on line 1 of line.
+func (f *Format) Decode(line []byte, dst ...any) error {
+	targets, err := f.targets(dst)
+	if err != nil {
+		return err
+	}
+	d := decoder{src: line}
+	if n := len(d.src); n > 0 && d.src[n-1] == '\r' {
+		d.src = d.src[:n-1]
+	}
+	verb := 0
+	for i, op := range f.ops {
+		switch op.kind {
+		case opLiteral:
+			if !d.literal(op.literal) {
+				return d.errorf("expected %q", op.literal)
+			}
+		case opSpace:
+			if !d.spaces() {
+				return d.errorf("expected whitespace")
+			}
+		case opVerb:
+			if err := d.verb(op, f.stopByte(i), targets[verb]); err != nil {
+				return err
+			}
+			verb++
+		}
+	}
+	if d.off != len(d.src) {
+		return d.errorf("unexpected %q after record", d.src[d.off:])
+	}
+	return nil
+}
+
+// stopByte is the first byte of the literal following op i, which ends a %s.
+func (f *Format) stopByte(i int) byte {
+	if i+1 < len(f.ops) && f.ops[i+1].kind == opLiteral {
+		return f.ops[i+1].literal[0]
+	}
+	return 0
+}
+
+func (f *Format) targets(dst []any) ([]reflect.Value, error) {
+	if len(dst) == 1 {
+		v := reflect.ValueOf(dst[0])
+		if v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+			fields := make([]reflect.Value, 0, f.verbs)
+			st := v.Elem()
+			for i := range st.NumField() {
+				if st.Type().Field(i).IsExported() {
+					fields = append(fields, st.Field(i))
+				}
+			}
+			if len(fields) != f.verbs {
+				return nil, fmt.Errorf("Format.Decode() %q has %d verbs but %s has %d exported fields", f.pattern, f.verbs, st.Type(), len(fields))
+			}
+			return fields, nil
+		}
+	}
+	if len(dst) != f.verbs {
+		return nil, fmt.Errorf("Format.Decode() %q has %d verbs but got %d targets", f.pattern, f.verbs, len(dst))
+	}
+	res := make([]reflect.Value, len(dst))
+	for i, d := range dst {
+		v := reflect.ValueOf(d)
+		if v.Kind() != reflect.Pointer || v.IsNil() {
+			return nil, fmt.Errorf("Format.Decode() target %d is %T, not a pointer", i, d)
+		}
+		res[i] = v.Elem()
+	}
+	return res, nil
+}
+
+type decoder struct {
+	src []byte
+	off int
+}
+
+func (d *decoder) errorf(format string, args ...any) error {
+	return &SyntaxError{1, d.off + 1, fmt.Sprintf(format, args...)}
+}
+
+func (d *decoder) literal(s string) bool {
+	if !bytes.HasPrefix(d.src[d.off:], []byte(s)) {
+		return false
+	}
+	d.off += len(s)
+	return true
+}
+
+func (d *decoder) spaces() bool {
+	start := d.off
+	for d.off < len(d.src) && isSpace(d.src[d.off]) {
+		d.off++
+	}
+	return d.off > start
+}
+
+func (d *decoder) verb(op formatOp, stop byte, target reflect.Value) error {
+	if !op.repeat {
+		return d.item(op.verb, stop, target)
+	}
+	if target.Kind() != reflect.Slice {
+		return fmt.Errorf("Format.Decode() repeated %%%c needs a slice, not %s", op.verb, target.Type())
+	}
+	if op.sep != 0 {
+		stop = op.sep
+	}
+	list := reflect.MakeSlice(target.Type(), 0, 8)
+	for {
+		elem := reflect.New(target.Type().Elem()).Elem()
+		if err := d.item(op.verb, stop, elem); err != nil {
+			return err
+		}
+		list = reflect.Append(list, elem)
+		if op.sep != 0 {
+			if d.off >= len(d.src) || d.src[d.off] != op.sep {
+				break
+			}
+			d.off++
+			continue
+		}
+		// whitespace separated lists end wherever the next item does not start.
+		save := d.off
+		if !d.spaces() || !d.startsItem(op.verb) {
+			d.off = save
+			break
+		}
+	}
+	target.Set(list)
+	return nil
+}
+
+func (d *decoder) startsItem(verb byte) bool {
+	if d.off >= len(d.src) {
+		return false
+	}
+	if verb == 's' {
+		return true
+	}
+	c := d.src[d.off]
+	if c == '-' || c == '+' {
+		return d.off+1 < len(d.src) && isDigit(d.src[d.off+1])
+	}
+	return isDigit(c)
+}
+
+func (d *decoder) item(verb byte, stop byte, target reflect.Value) error {
+	start := d.off
+	if verb == 's' {
+		for d.off < len(d.src) && !isSpace(d.src[d.off]) && (stop == 0 || d.src[d.off] != stop) {
+			d.off++
+		}
+		if d.off == start {
+			return d.errorf("expected a word")
+		}
+		if target.Kind() != reflect.String {
+			return fmt.Errorf("Format.Decode() %%s needs a string, not %s", target.Type())
+		}
+		target.SetString(string(d.src[start:d.off]))
+		return nil
+	}
+	neg := false
+	if d.off < len(d.src) && (d.src[d.off] == '-' || d.src[d.off] == '+') {
+		neg = d.src[d.off] == '-'
+		d.off++
+	}
+	if d.off >= len(d.src) || !isDigit(d.src[d.off]) {
+		return d.errorf("expected digit")
+	}
+	n := 0
+	for d.off < len(d.src) && isDigit(d.src[d.off]) {
+		c := int(d.src[d.off] - '0')
+		if n > (math.MaxInt-c)/10 {
+			d.off = start
+			return d.errorf("integer overflows int")
+		}
+		n = n*10 + c
+		d.off++
+	}
+	if neg {
+		n = -n
+	}
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if target.OverflowInt(int64(n)) {
+			d.off = start
+			return d.errorf("integer overflows %s", target.Type())
+		}
+		target.SetInt(int64(n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n < 0 || target.OverflowUint(uint64(n)) {
+			d.off = start
+			return d.errorf("integer overflows %s", target.Type())
+		}
+		target.SetUint(uint64(n))
+	default:
+		return fmt.Errorf("Format.Decode() %%d needs an integer, not %s", target.Type())
+	}
+	return nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
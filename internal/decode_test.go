@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	var (
+		a, b  int
+		s1    string
+		s2    string
+		list  []int
+		words []string
+		small uint8
+		tiny  int8
+	)
+	reset := func() {
+		a, b, s1, s2, list, words, small, tiny = 0, 0, "", "", nil, nil, 0, 0
+	}
+	tests := []struct {
+		pattern string
+		line    string
+		dst     []any
+		want    []any
+	}{
+		{"%d|%d", "47|-53", []any{&a, &b}, []any{47, -53}},
+		{"%d|%d", "47|53\r", []any{&a, &b}, []any{47, 53}},
+		{"%s-%s", "ab-cd", []any{&s1, &s2}, []any{"ab", "cd"}},
+		{"%s %s", "ab  \tcd", []any{&s1, &s2}, []any{"ab", "cd"}},
+		{"%s=%d", "x=+7", []any{&s1, &a}, []any{"x", 7}},
+		{"%d: %d...", "190: 10 -19\t 3", []any{&a, &list}, []any{190, []int{10, -19, 3}}},
+		{"%d: %d...", "5: 1", []any{&a, &list}, []any{5, []int{1}}},
+		{"%d,...", "75,47,61", []any{&list}, []any{[]int{75, 47, 61}}},
+		{"%s,...", "a,bc,d e", []any{&words}, nil},
+		{"%s,... %d", "a,bc 9", []any{&words, &a}, []any{[]string{"a", "bc"}, 9}},
+		{"%d%%", "50%", []any{&a}, []any{50}},
+		{"%%%d", "%3", []any{&a}, []any{3}},
+		{"%d", "255", []any{&small}, []any{uint8(255)}},
+		{"%d", "-128", []any{&tiny}, []any{int8(-128)}},
+	}
+	for _, tt := range tests {
+		reset()
+		err := MustFormat(tt.pattern).Decode([]byte(tt.line), tt.dst...)
+		if tt.want == nil {
+			if err == nil {
+				t.Errorf("Decode(%q, %q) succeeded, want an error", tt.pattern, tt.line)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Decode(%q, %q): %v", tt.pattern, tt.line, err)
+			continue
+		}
+		for i, d := range tt.dst {
+			if got := reflect.ValueOf(d).Elem().Interface(); !reflect.DeepEqual(got, tt.want[i]) {
+				t.Errorf("Decode(%q, %q) target %d = %#v, want %#v", tt.pattern, tt.line, i, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestDecodeStruct(t *testing.T) {
+	type record struct {
+		ID     int
+		hidden string
+		Name   string
+		Values []uint16
+	}
+	var r record
+	r.hidden = "kept"
+	if err := MustFormat("#%d %s: %d,...").Decode([]byte("#3 foo: 1,2,3"), &r); err != nil {
+		t.Fatal(err)
+	}
+	want := record{3, "kept", "foo", []uint16{1, 2, 3}}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("Decode() = %+v, want %+v", r, want)
+	}
+}
+
+func TestDecodeSyntaxErrors(t *testing.T) {
+	var (
+		a, b  int
+		list  []int
+		small uint8
+		tiny  int8
+		u     uint
+	)
+	tests := []struct {
+		pattern string
+		line    string
+		dst     []any
+		col     int
+	}{
+		{"%d|%d", "47,53", []any{&a, &b}, 3},
+		{"%d|%d", "47|", []any{&a, &b}, 4},
+		{"%d|%d", "47|53x", []any{&a, &b}, 6},
+		{"%d: %d...", "190: 10 19 ", []any{&a, &list}, 11},
+		{"%d: %d...", "190:10", []any{&a, &list}, 5},
+		{"%d,...", "1,2,", []any{&list}, 5},
+		{"%d%%", "50", []any{&a}, 3},
+		{"%d", "300", []any{&small}, 1},
+		{"%d", "-129", []any{&tiny}, 1},
+		{"%d", "-1", []any{&u}, 1},
+		{"%d", "99999999999999999999", []any{&a}, 1},
+	}
+	for _, tt := range tests {
+		err := MustFormat(tt.pattern).Decode([]byte(tt.line), tt.dst...)
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Errorf("Decode(%q, %q) = %v, want a *SyntaxError", tt.pattern, tt.line, err)
+			continue
+		}
+		if se.Line != 1 || se.Col != tt.col {
+			t.Errorf("Decode(%q, %q) error at %d:%d, want 1:%d (%v)", tt.pattern, tt.line, se.Line, se.Col, tt.col, err)
+		}
+	}
+}
+
+func TestDecodeTargetErrors(t *testing.T) {
+	var (
+		a   int
+		s   string
+		f   float64
+		two struct{ A, B int }
+	)
+	tests := []struct {
+		name    string
+		pattern string
+		dst     []any
+	}{
+		{"too few targets", "%d|%d", []any{&a}},
+		{"too many targets", "%d", []any{&a, &a}},
+		{"not a pointer", "%d", []any{a}},
+		{"nil pointer", "%d", []any{(*int)(nil)}},
+		{"string for %d", "%d", []any{&s}},
+		{"float for %d", "%d", []any{&f}},
+		{"int for %s", "%s", []any{&a}},
+		{"int for a repeat", "%d,...", []any{&a}},
+		{"struct field count", "%d", []any{&two}},
+	}
+	for _, tt := range tests {
+		err := MustFormat(tt.pattern).Decode([]byte("1"), tt.dst...)
+		var se *SyntaxError
+		if err == nil || errors.As(err, &se) {
+			t.Errorf("%s: Decode() = %v, want a non-syntax error", tt.name, err)
+		}
+	}
+}
+
+func TestCompileFormatErrors(t *testing.T) {
+	for _, pattern := range []string{"%x", "abc%", "%d|%q"} {
+		if _, err := CompileFormat(pattern); err == nil {
+			t.Errorf("CompileFormat(%q) succeeded, want an error", pattern)
+		}
+	}
+}
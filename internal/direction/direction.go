@@ -0,0 +1,126 @@
+// Package direction defines the eight compass directions on a grid, so
+// simulations and ray scans agree on headings, turns and step offsets.
+package direction
+
+import (
+	"fmt"
+
+	"github.com/jdpolicano/aof-go/internal"
+)
+
+// Direction is a compass heading. The values run clockwise from North in
+// steps of 45 degrees, so cardinal directions are the even ones.
+type Direction uint8
+
+const (
+	N Direction = iota
+	NE
+	E
+	SE
+	S
+	SW
+	W
+	NW
+)
+
+// Count is the number of directions.
+const Count = 8
+
+var (
+	// Cardinal holds the four orthogonal directions, clockwise from North.
+	Cardinal = [...]Direction{N, E, S, W}
+	// Diagonal holds the four diagonal directions, clockwise from North-East.
+	Diagonal = [...]Direction{NE, SE, SW, NW}
+	// All holds all eight directions, clockwise from North.
+	All = [...]Direction{N, NE, E, SE, S, SW, W, NW}
+)
+
+var names = [Count]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+// deltas are the row and column offsets of one step, rows growing downwards.
+var deltas = [Count]internal.Location{
+	N:  {-1, 0},
+	NE: {-1, 1},
+	E:  {0, 1},
+	SE: {1, 1},
+	S:  {1, 0},
+	SW: {1, -1},
+	W:  {0, -1},
+	NW: {-1, -1},
+}
+
+func (d Direction) String() string {
+	if !d.Valid() {
+		return fmt.Sprintf("Direction(%d)", uint8(d))
+	}
+	return names[d]
+}
+
+func (d Direction) Valid() bool {
+	return d < Count
+}
+
+func (d Direction) IsCardinal() bool {
+	return d%2 == 0
+}
+
+// Clockwise turns d clockwise by the given number of 45 degree steps.
+// Negative steps turn counter-clockwise.
+func (d Direction) Clockwise(steps int) Direction {
+	return Direction(((int(d)+steps)%Count + Count) % Count)
+}
+
+// CounterClockwise turns d counter-clockwise by the given number of 45 degree steps.
+func (d Direction) CounterClockwise(steps int) Direction {
+	return d.Clockwise(-steps)
+}
+
+// Right is a quarter turn clockwise.
+func (d Direction) Right() Direction {
+	return d.Clockwise(2)
+}
+
+// Left is a quarter turn counter-clockwise.
+func (d Direction) Left() Direction {
+	return d.Clockwise(-2)
+}
+
+func (d Direction) Opposite() Direction {
+	return d.Clockwise(4)
+}
+
+// Delta is the offset of a single step in direction d.
+func (d Direction) Delta() internal.Location {
+	return deltas[d%Count]
+}
+
+// Step returns the location one step from p in direction d.
+func (d Direction) Step(p internal.Location) internal.Location {
+	return p.Add(d.Delta())
+}
+
+// Parse reads a direction written as an arrow (^ > v <), a compass point
+// (N, NE, E, ...) or a single letter of U, R, D, L.
+func Parse(s string) (Direction, error) {
+	switch s {
+	case "^", "U":
+		return N, nil
+	case ">", "R":
+		return E, nil
+	case "v", "D":
+		return S, nil
+	case "<", "L":
+		return W, nil
+	}
+	for d, name := range names {
+		if name == s {
+			return Direction(d), nil
+		}
+	}
+	return 0, fmt.Errorf("direction.Parse() unknown direction %q", s)
+}
+
+// ParseByte is Parse for a single character.
+func ParseByte(b byte) (Direction, error) {
+	return Parse(string(b))
+}
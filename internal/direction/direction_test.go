@@ -0,0 +1,84 @@
+package direction
+
+import (
+	"testing"
+
+	"github.com/jdpolicano/aof-go/internal"
+)
+
+func TestClockwise(t *testing.T) {
+	tests := []struct {
+		d     Direction
+		steps int
+		want  Direction
+	}{
+		{N, 0, N},
+		{N, 1, NE},
+		{N, 2, E},
+		{NW, 1, N},
+		{N, -1, NW},
+		{E, -2, N},
+		{S, -6, W},
+		{N, 8, N},
+		{N, 17, NE},
+		{W, -9, SW},
+		{SE, -16, SE},
+		{SE, -21, W},
+	}
+	for _, tt := range tests {
+		if got := tt.d.Clockwise(tt.steps); got != tt.want {
+			t.Errorf("%v.Clockwise(%d) = %v, want %v", tt.d, tt.steps, got, tt.want)
+		}
+		if got := tt.d.CounterClockwise(-tt.steps); got != tt.want {
+			t.Errorf("%v.CounterClockwise(%d) = %v, want %v", tt.d, -tt.steps, got, tt.want)
+		}
+	}
+}
+
+func TestOpposite(t *testing.T) {
+	for _, d := range All {
+		o := d.Opposite()
+		if o.Delta() != d.Delta().Neg() {
+			t.Errorf("%v.Opposite() = %v, which steps %v", d, o, o.Delta())
+		}
+		if o.Opposite() != d {
+			t.Errorf("%v.Opposite().Opposite() = %v", d, o.Opposite())
+		}
+		if d.Right().Delta() != d.Delta().RotateCW() || d.Left().Delta() != d.Delta().RotateCCW() {
+			t.Errorf("%v.Right() and Left() disagree with rotating %v", d, d.Delta())
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Direction
+	}{
+		{"^", N}, {"U", N}, {"N", N},
+		{">", E}, {"R", E}, {"E", E},
+		{"v", S}, {"D", S}, {"S", S},
+		{"<", W}, {"L", W}, {"W", W},
+		{"NE", NE}, {"SE", SE}, {"SW", SW}, {"NW", NW},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "n", "V", "up", "NNE", "Direction(8)"} {
+		if got, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %v, want an error", in, got)
+		}
+	}
+	if got, err := ParseByte('<'); err != nil || got != W {
+		t.Errorf("ParseByte('<') = %v, %v, want W", got, err)
+	}
+	if d := Direction(8); d.Valid() || d.String() != "Direction(8)" {
+		t.Errorf("Direction(8) is %v, valid %t", d, d.Valid())
+	}
+	if got := SW.Step(internal.Location{2, 2}); got != (internal.Location{3, 1}) {
+		t.Errorf("SW.Step({2, 2}) = %v, want {3, 1}", got)
+	}
+}
@@ -0,0 +1,232 @@
+// Package graph is a small directed graph for ordering problems. Nodes and
+// edges remember the order they were added in, so sorts and reported cycles
+// come out the same on every run.
+package graph
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/jdpolicano/aof-go/internal"
+)
+
+type Graph[T comparable] struct {
+	index map[T]int
+	nodes []T
+	out   [][]int
+	in    []int
+	edges *internal.Set[[2]int]
+}
+
+func New[T comparable]() *Graph[T] {
+	return &Graph[T]{index: make(map[T]int), edges: internal.NewSet[[2]int]()}
+}
+
+// FromEdges builds a graph holding every from -> to pair in edges.
+func FromEdges[T comparable](edges iter.Seq2[T, T]) *Graph[T] {
+	g := New[T]()
+	for from, to := range edges {
+		g.AddEdge(from, to)
+	}
+	return g
+}
+
+// AddNode adds v if it is not already present and returns its index.
+func (g *Graph[T]) AddNode(v T) int {
+	if i, exists := g.index[v]; exists {
+		return i
+	}
+	i := len(g.nodes)
+	g.index[v] = i
+	g.nodes = append(g.nodes, v)
+	g.out = append(g.out, nil)
+	g.in = append(g.in, 0)
+	return i
+}
+
+// AddEdge adds the edge from -> to, adding either node if needed. Adding
+// an edge twice has no effect.
+func (g *Graph[T]) AddEdge(from, to T) {
+	f, t := g.AddNode(from), g.AddNode(to)
+	if !g.edges.Add([2]int{f, t}) {
+		return
+	}
+	g.out[f] = append(g.out[f], t)
+	g.in[t]++
+}
+
+func (g *Graph[T]) Has(v T) bool {
+	_, exists := g.index[v]
+	return exists
+}
+
+func (g *Graph[T]) HasEdge(from, to T) bool {
+	f, fok := g.index[from]
+	t, tok := g.index[to]
+	return fok && tok && g.edges.Has([2]int{f, t})
+}
+
+// Len is the number of nodes.
+func (g *Graph[T]) Len() int {
+	return len(g.nodes)
+}
+
+// Nodes returns every node in the order it was added.
+func (g *Graph[T]) Nodes() []T {
+	return append([]T(nil), g.nodes...)
+}
+
+// Successors returns the nodes v has an edge to, in the order the edges
+// were added.
+func (g *Graph[T]) Successors(v T) []T {
+	i, exists := g.index[v]
+	if !exists {
+		return nil
+	}
+	res := make([]T, len(g.out[i]))
+	for j, t := range g.out[i] {
+		res[j] = g.nodes[t]
+	}
+	return res
+}
+
+// Subgraph returns the graph induced by keep: those nodes, in keep's order,
+// and every edge of g between two of them. Nodes g does not have are added
+// without edges.
+func (g *Graph[T]) Subgraph(keep []T) *Graph[T] {
+	sub := New[T]()
+	for _, v := range keep {
+		sub.AddNode(v)
+	}
+	for _, v := range sub.nodes {
+		i, exists := g.index[v]
+		if !exists {
+			continue
+		}
+		for _, t := range g.out[i] {
+			if to := g.nodes[t]; sub.Has(to) {
+				sub.AddEdge(v, to)
+			}
+		}
+	}
+	return sub
+}
+
+// CycleError is returned when a graph that must be acyclic is not. Cycle
+// lists the nodes around the loop, the first one repeated at the end.
+type CycleError[T comparable] struct {
+	Cycle []T
+}
+
+func (e *CycleError[T]) Error() string {
+	parts := make([]string, len(e.Cycle))
+	for i, v := range e.Cycle {
+		parts[i] = fmt.Sprint(v)
+	}
+	return "graph has a cycle: " + strings.Join(parts, " -> ")
+}
+
+// TopoSort orders the nodes so every edge points forward, using Kahn's
+// algorithm. Nodes go in the order they become free, those free from the
+// start in the order they were added. A cyclic graph returns a *CycleError.
+func (g *Graph[T]) TopoSort() ([]T, error) {
+	in := append([]int(nil), g.in...)
+	queue := make([]int, 0, len(g.nodes))
+	for i := range g.nodes {
+		if in[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	res := make([]T, 0, len(g.nodes))
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		res = append(res, g.nodes[i])
+		for _, t := range g.out[i] {
+			in[t]--
+			if in[t] == 0 {
+				queue = append(queue, t)
+			}
+		}
+	}
+	if len(res) < len(g.nodes) {
+		return nil, &CycleError[T]{g.FindCycle()}
+	}
+	return res, nil
+}
+
+// TopoSortDFS orders the nodes so every edge points forward, using a depth
+// first search from each node in the order they were added. A cyclic graph
+// returns a *CycleError.
+func (g *Graph[T]) TopoSortDFS() ([]T, error) {
+	order := make([]int, 0, len(g.nodes))
+	if cycle := g.dfs(func(i int) { order = append(order, i) }); cycle != nil {
+		return nil, &CycleError[T]{cycle}
+	}
+	res := make([]T, len(order))
+	for j, i := range order {
+		res[len(order)-1-j] = g.nodes[i]
+	}
+	return res, nil
+}
+
+// FindCycle returns a cycle in the graph with its first node repeated at the
+// end, or nil if the graph is acyclic.
+func (g *Graph[T]) FindCycle() []T {
+	return g.dfs(func(int) {})
+}
+
+const (
+	unvisited = iota
+	onStack
+	done
+)
+
+// dfs walks the whole graph, calling finish on each node once everything
+// reachable from it is done, and stops at the first cycle it finds.
+func (g *Graph[T]) dfs(finish func(int)) []T {
+	state := make([]uint8, len(g.nodes))
+	stack := make([]int, 0, len(g.nodes))
+	var visit func(i int) []T
+	visit = func(i int) []T {
+		state[i] = onStack
+		stack = append(stack, i)
+		for _, t := range g.out[i] {
+			switch state[t] {
+			case onStack:
+				return g.cycleFrom(stack, t)
+			case unvisited:
+				if cycle := visit(t); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[i] = done
+		finish(i)
+		return nil
+	}
+	for i := range g.nodes {
+		if state[i] == unvisited {
+			if cycle := visit(i); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// cycleFrom reads the cycle closed by an edge back to start off the stack.
+func (g *Graph[T]) cycleFrom(stack []int, start int) []T {
+	res := make([]T, 0, len(stack)+1)
+	for j := len(stack) - 1; j >= 0; j-- {
+		if stack[j] == start {
+			for _, i := range stack[j:] {
+				res = append(res, g.nodes[i])
+			}
+			break
+		}
+	}
+	return append(res, g.nodes[start])
+}
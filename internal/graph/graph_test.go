@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func fromPairs(pairs ...[2]int) *Graph[int] {
+	g := New[int]()
+	for _, p := range pairs {
+		g.AddEdge(p[0], p[1])
+	}
+	return g
+}
+
+func TestTopoSort(t *testing.T) {
+	tests := []struct {
+		name  string
+		g     *Graph[int]
+		kahn  []int
+		dfs   []int
+		cycle []int
+	}{
+		{
+			name: "acyclic",
+			g:    fromPairs([2]int{5, 3}, [2]int{1, 3}, [2]int{3, 7}, [2]int{1, 5}),
+			kahn: []int{1, 5, 3, 7},
+			dfs:  []int{1, 5, 3, 7},
+		},
+		{
+			name: "nodes go in the order they become free",
+			g:    fromPairs([2]int{4, 2}, [2]int{3, 1}),
+			kahn: []int{4, 3, 2, 1},
+			dfs:  []int{3, 1, 4, 2},
+		},
+		{
+			name:  "self-loop",
+			g:     fromPairs([2]int{1, 2}, [2]int{2, 2}),
+			cycle: []int{2, 2},
+		},
+		{
+			name:  "cycle after an acyclic prefix",
+			g:     fromPairs([2]int{0, 1}, [2]int{1, 2}, [2]int{2, 3}, [2]int{3, 1}, [2]int{3, 4}),
+			cycle: []int{1, 2, 3, 1},
+		},
+	}
+	for _, tt := range tests {
+		kahn, kerr := tt.g.TopoSort()
+		dfs, derr := tt.g.TopoSortDFS()
+		cycle := tt.g.FindCycle()
+		if tt.cycle == nil {
+			if kerr != nil || derr != nil || cycle != nil {
+				t.Errorf("%s: errors %v, %v and cycle %v, want none", tt.name, kerr, derr, cycle)
+				continue
+			}
+			if !slices.Equal(kahn, tt.kahn) {
+				t.Errorf("%s: TopoSort() = %v, want %v", tt.name, kahn, tt.kahn)
+			}
+			if !slices.Equal(dfs, tt.dfs) {
+				t.Errorf("%s: TopoSortDFS() = %v, want %v", tt.name, dfs, tt.dfs)
+			}
+			continue
+		}
+		if !slices.Equal(cycle, tt.cycle) {
+			t.Errorf("%s: FindCycle() = %v, want %v", tt.name, cycle, tt.cycle)
+		}
+		for sort, err := range map[string]error{"TopoSort": kerr, "TopoSortDFS": derr} {
+			var ce *CycleError[int]
+			if !errors.As(err, &ce) {
+				t.Errorf("%s: %s() = %v, want a *CycleError", tt.name, sort, err)
+				continue
+			}
+			if !slices.Equal(ce.Cycle, tt.cycle) {
+				t.Errorf("%s: %s() cycle = %v, want %v", tt.name, sort, ce.Cycle, tt.cycle)
+			}
+		}
+		if kahn != nil || dfs != nil {
+			t.Errorf("%s: sorted a cyclic graph into %v and %v", tt.name, kahn, dfs)
+		}
+	}
+}
+
+func TestCycleErrorMessage(t *testing.T) {
+	_, err := fromPairs([2]int{1, 2}, [2]int{2, 3}, [2]int{3, 1}).TopoSort()
+	if err == nil || err.Error() != "graph has a cycle: 1 -> 2 -> 3 -> 1" {
+		t.Errorf("TopoSort() = %v", err)
+	}
+}
+
+func TestSubgraph(t *testing.T) {
+	// the full graph has a cycle through 2, which the subgraph leaves out.
+	g := fromPairs([2]int{1, 2}, [2]int{2, 3}, [2]int{1, 3}, [2]int{3, 2})
+	sub := g.Subgraph([]int{3, 9, 1})
+	if got, want := sub.Nodes(), []int{3, 9, 1}; !slices.Equal(got, want) {
+		t.Errorf("Nodes() = %v, want %v", got, want)
+	}
+	if !sub.HasEdge(1, 3) || sub.HasEdge(1, 2) || sub.Has(2) {
+		t.Errorf("Subgraph() kept the wrong edges: 1 -> %v", sub.Successors(1))
+	}
+	if got := sub.Successors(9); len(got) != 0 {
+		t.Errorf("Successors(9) = %v, want none", got)
+	}
+	order, err := sub.TopoSort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{9, 1, 3}; !slices.Equal(order, want) {
+		t.Errorf("TopoSort() = %v, want %v", order, want)
+	}
+	if g.Has(9) {
+		t.Error("Subgraph() added a node to the original graph")
+	}
+}
@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+)
+
+var (
+	neighbors4 = [...]Location{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}
+	neighbors8 = [...]Location{{-1, 0}, {-1, 1}, {0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}}
+)
+
+// Grid is a rectangular, row-major 2D array addressed by Location.
+type Grid[T any] struct {
+	width  int
+	height int
+	cells  []T
+}
+
+func NewGrid[T any](width, height int) *Grid[T] {
+	return &Grid[T]{width, height, make([]T, width*height)}
+}
+
+// ParseGrid reads a grid of bytes, one row per line. Surrounding blank lines
+// and carriage returns are ignored, but every row must have the same width.
+func ParseGrid(input []byte) (*Grid[byte], error) {
+	return ParseGridFunc(input, func(b byte) (byte, error) { return b, nil })
+}
+
+// ParseGridFunc reads a grid one row per line, converting every byte with f.
+func ParseGridFunc[T any](input []byte, f func(byte) (T, error)) (*Grid[T], error) {
+	trimmed := bytes.Trim(input, "\r\n")
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("ParseGrid() empty input")
+	}
+	lines := bytes.Split(trimmed, []byte("\n"))
+	width := len(bytes.TrimSuffix(lines[0], []byte("\r")))
+	g := &Grid[T]{width, len(lines), make([]T, 0, width*len(lines))}
+	for r, line := range lines {
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if len(line) != width {
+			return nil, fmt.Errorf("ParseGrid() line %d has %d columns, want %d", r+1, len(line), width)
+		}
+		for c, b := range line {
+			v, err := f(b)
+			if err != nil {
+				return nil, fmt.Errorf("ParseGrid() line %d column %d: %w", r+1, c+1, err)
+			}
+			g.cells = append(g.cells, v)
+		}
+	}
+	return g, nil
+}
+
+func (g *Grid[T]) Width() int {
+	return g.width
+}
+
+func (g *Grid[T]) Height() int {
+	return g.height
+}
+
+func (g *Grid[T]) InBounds(p Location) bool {
+	return InRange(0, g.height-1, p.Row()) && InRange(0, g.width-1, p.Col())
+}
+
+// Get returns the value at p, or the zero value and false when p is outside the grid.
+func (g *Grid[T]) Get(p Location) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Row()*g.width+p.Col()], true
+}
+
+// Ptr returns a pointer to the cell at p, or nil when p is outside the grid.
+func (g *Grid[T]) Ptr(p Location) *T {
+	if !g.InBounds(p) {
+		return nil
+	}
+	return &g.cells[p.Row()*g.width+p.Col()]
+}
+
+// Set stores v at p and reports whether p was inside the grid.
+func (g *Grid[T]) Set(p Location, v T) bool {
+	if !g.InBounds(p) {
+		return false
+	}
+	g.cells[p.Row()*g.width+p.Col()] = v
+	return true
+}
+
+// Row returns row r. The slice shares storage with the grid.
+func (g *Grid[T]) Row(r int) []T {
+	if !InRange(0, g.height-1, r) {
+		return nil
+	}
+	return g.cells[r*g.width : (r+1)*g.width : (r+1)*g.width]
+}
+
+// Col returns a copy of column c.
+func (g *Grid[T]) Col(c int) []T {
+	if !InRange(0, g.width-1, c) {
+		return nil
+	}
+	col := make([]T, g.height)
+	for r := range col {
+		col[r] = g.cells[r*g.width+c]
+	}
+	return col
+}
+
+// Neighbors4 returns the orthogonal neighbors of p that are inside the grid,
+// clockwise from the one above.
+func (g *Grid[T]) Neighbors4(p Location) []Location {
+	return g.neighbors(p, neighbors4[:])
+}
+
+// Neighbors8 returns the orthogonal and diagonal neighbors of p that are
+// inside the grid, clockwise from the one above.
+func (g *Grid[T]) Neighbors8(p Location) []Location {
+	return g.neighbors(p, neighbors8[:])
+}
+
+func (g *Grid[T]) neighbors(p Location, offsets []Location) []Location {
+	res := make([]Location, 0, len(offsets))
+	for _, o := range offsets {
+		n := p.Add(o)
+		if g.InBounds(n) {
+			res = append(res, n)
+		}
+	}
+	return res
+}
+
+// FindFunc returns the first location, in row-major order, whose value satisfies f.
+func (g *Grid[T]) FindFunc(f func(T) bool) (Location, bool) {
+	for i, v := range g.cells {
+		if f(v) {
+			return Location{i / g.width, i % g.width}, true
+		}
+	}
+	return Location{}, false
+}
+
+// FindAllFunc returns every location, in row-major order, whose value satisfies f.
+func (g *Grid[T]) FindAllFunc(f func(T) bool) []Location {
+	res := make([]Location, 0, 16)
+	for i, v := range g.cells {
+		if f(v) {
+			res = append(res, Location{i / g.width, i % g.width})
+		}
+	}
+	return res
+}
+
+func (g *Grid[T]) Clone() *Grid[T] {
+	cells := make([]T, len(g.cells))
+	copy(cells, g.cells)
+	return &Grid[T]{g.width, g.height, cells}
+}
+
+// Find returns the first location holding v.
+func Find[T comparable](g *Grid[T], v T) (Location, bool) {
+	return g.FindFunc(func(x T) bool { return x == v })
+}
+
+// FindAll returns every location holding v.
+func FindAll[T comparable](g *Grid[T], v T) []Location {
+	return g.FindAllFunc(func(x T) bool { return x == v })
+}
@@ -0,0 +1,41 @@
+package internal
+
+import "testing"
+
+func TestParseGrid(t *testing.T) {
+	tests := []struct {
+		input         string
+		width, height int
+		wantErr       bool
+	}{
+		{"ab\ncd\n", 2, 2, false},
+		{"\n\nab\r\ncd\r\n\r\n", 2, 2, false},
+		{" ab\ncd \n", 3, 2, false},
+		{" ab\ncd\n", 0, 0, true},
+		{"\n\r\n", 0, 0, true},
+	}
+	for _, tt := range tests {
+		g, err := ParseGrid([]byte(tt.input))
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseGrid(%q) = %dx%d, want an error", tt.input, g.Width(), g.Height())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseGrid(%q): %v", tt.input, err)
+			continue
+		}
+		if g.Width() != tt.width || g.Height() != tt.height {
+			t.Errorf("ParseGrid(%q) = %dx%d, want %dx%d", tt.input, g.Width(), g.Height(), tt.width, tt.height)
+		}
+	}
+	// spaces are cells like any other byte.
+	g, _ := ParseGrid([]byte(" ab\ncd \n"))
+	if v, _ := g.Get(Location{0, 0}); v != ' ' {
+		t.Errorf("Get({0, 0}) = %q, want ' '", v)
+	}
+	if v, _ := g.Get(Location{1, 2}); v != ' ' {
+		t.Errorf("Get({1, 2}) = %q, want ' '", v)
+	}
+}
@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+)
+
+// DaysDir is the directory under the module root holding one package per day.
+const DaysDir = "days"
+
+// RootEnv overrides the module root used to locate puzzle inputs.
+const RootEnv = "AOC_ROOT"
+
+// Root returns the module root so inputs can be found no matter which
+// directory the command is run from. It prefers $AOC_ROOT, then the source
+// tree this binary was built from, then the nearest parent of the working
+// directory containing a go.mod.
+func Root() (string, error) {
+	if root := os.Getenv(RootEnv); root != "" {
+		return root, nil
+	}
+	if _, file, _, ok := runtime.Caller(0); ok {
+		root := filepath.Dir(filepath.Dir(file))
+		if isModuleRoot(root) {
+			return root, nil
+		}
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if isModuleRoot(dir) {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("Root() no go.mod found, set $%s", RootEnv)
+		}
+		dir = parent
+	}
+}
+
+func isModuleRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, "go.mod"))
+	return err == nil
+}
+
+// Dir returns the directory of the day's package, where its inputs live.
+func (d *Day) Dir() (string, error) {
+	root, err := Root()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, DaysDir, "day"+strconv.Itoa(d.Number)), nil
+}
+
+// InputPath returns the path of the day's puzzle input, or of its n-th example
+// when n > 0. The first example is test_input.txt (or test_input1.txt), later
+// ones are test_input2.txt, test_input3.txt and so on.
+func (d *Day) InputPath(example int) (string, error) {
+	dir, err := d.Dir()
+	if err != nil {
+		return "", err
+	}
+	if example <= 0 {
+		return filepath.Join(dir, "input.txt"), nil
+	}
+	if example == 1 {
+		path := filepath.Join(dir, "test_input.txt")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return filepath.Join(dir, "test_input"+strconv.Itoa(example)+".txt"), nil
+}
+
+// Inputs returns every input file in the day's directory: the puzzle input
+// followed by the examples in name order.
+func (d *Day) Inputs() ([]string, error) {
+	dir, err := d.Dir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "test_input*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	input := filepath.Join(dir, "input.txt")
+	if _, err := os.Stat(input); err == nil {
+		paths = append([]string{input}, paths...)
+	}
+	return paths, nil
+}
+
+// ReadInput reads the input for the day. An explicit path wins, "-" reads
+// standard input, otherwise the puzzle input or the selected example is read
+// from the day's directory.
+func (d *Day) ReadInput(path string, example int) ([]byte, error) {
+	if path != "" && example > 0 {
+		return nil, errors.New("ReadInput() an explicit input and an example are mutually exclusive")
+	}
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	if path == "" {
+		p, err := d.InputPath(example)
+		if err != nil {
+			return nil, err
+		}
+		path = p
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,189 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// SyntaxError is malformed puzzle input at a 1-based line and column.
+type SyntaxError struct {
+	Line int
+	Col  int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, col %d: %s", e.Line, e.Col, e.Msg)
+}
+
+// IntScanner reads integers straight from input bytes. In strict mode only
+// the configured separators, '\r' and '\n' may appear between integers and
+// anything else is a SyntaxError; in lenient mode every other byte is
+// skipped.
+type IntScanner struct {
+	src     []byte
+	seps    string
+	lenient bool
+	// Signed allows a leading '-' or '+' on an integer.
+	Signed bool
+
+	off       int
+	line      int
+	lineStart int
+	val       int
+	tokLine   int
+	tokCol    int
+	err       error
+}
+
+// NewIntScanner returns a strict scanner over src whose integers are
+// separated by any of the bytes in seps.
+func NewIntScanner(src []byte, seps string) *IntScanner {
+	return &IntScanner{src: src, seps: seps, line: 1, tokLine: 1, tokCol: 1}
+}
+
+// Ints extracts every integer in b, skipping whatever lies between them.
+// The only possible error is an integer that overflows an int.
+func Ints(b []byte, signed bool) ([]int, error) {
+	s := &IntScanner{src: b, lenient: true, Signed: signed, line: 1}
+	res := make([]int, 0, 16)
+	for s.Next() {
+		res = append(res, s.Int())
+	}
+	return res, s.Err()
+}
+
+// Int is the integer read by the last successful Next.
+func (s *IntScanner) Int() int {
+	return s.val
+}
+
+// Err is the first error the scanner hit, if any.
+func (s *IntScanner) Err() error {
+	return s.err
+}
+
+// Pos is the line and column of the last integer read, or of the start of
+// the line last read by ScanLine.
+func (s *IntScanner) Pos() (line, col int) {
+	return s.tokLine, s.tokCol
+}
+
+// Errorf builds a SyntaxError at Pos, for callers rejecting input that
+// scanned fine but does not make sense.
+func (s *IntScanner) Errorf(format string, args ...any) error {
+	return &SyntaxError{s.tokLine, s.tokCol, fmt.Sprintf(format, args...)}
+}
+
+// Next reads the next integer, crossing line boundaries. It returns false
+// at the end of the input or on error.
+func (s *IntScanner) Next() bool {
+	for s.err == nil && s.off < len(s.src) {
+		if s.src[s.off] == '\n' {
+			s.newline()
+			continue
+		}
+		if s.skippable() {
+			s.off++
+			continue
+		}
+		return s.scanInt()
+	}
+	return false
+}
+
+// ScanLine appends the integers of the next line to dst. A blank line adds
+// nothing. It returns false at the end of the input or on error.
+func (s *IntScanner) ScanLine(dst []int) ([]int, bool) {
+	if s.err != nil || s.off >= len(s.src) {
+		return dst, false
+	}
+	s.tokLine, s.tokCol = s.line, 1
+	for s.off < len(s.src) {
+		if s.src[s.off] == '\n' {
+			s.newline()
+			return dst, true
+		}
+		if s.skippable() {
+			s.off++
+			continue
+		}
+		if !s.scanInt() {
+			return dst, false
+		}
+		dst = append(dst, s.val)
+	}
+	return dst, true
+}
+
+func (s *IntScanner) newline() {
+	s.off++
+	s.line++
+	s.lineStart = s.off
+}
+
+func (s *IntScanner) errorf(off int, format string, args ...any) bool {
+	s.err = &SyntaxError{s.line, off - s.lineStart + 1, fmt.Sprintf(format, args...)}
+	return false
+}
+
+func (s *IntScanner) isSep(c byte) bool {
+	return c == '\r' || c == '\n' || strings.IndexByte(s.seps, c) >= 0
+}
+
+// skippable reports whether the byte under the cursor can be stepped over
+// without starting an integer.
+func (s *IntScanner) skippable() bool {
+	c := s.src[s.off]
+	if s.isSep(c) {
+		return true
+	}
+	if !s.lenient {
+		return false
+	}
+	if isDigit(c) {
+		return false
+	}
+	if s.Signed && (c == '-' || c == '+') && s.off+1 < len(s.src) && isDigit(s.src[s.off+1]) {
+		return false
+	}
+	return true
+}
+
+func (s *IntScanner) scanInt() bool {
+	start := s.off
+	s.tokLine, s.tokCol = s.line, start-s.lineStart+1
+	neg := false
+	if s.Signed && (s.src[s.off] == '-' || s.src[s.off] == '+') {
+		neg = s.src[s.off] == '-'
+		s.off++
+	}
+	if s.off >= len(s.src) || !isDigit(s.src[s.off]) {
+		if s.off >= len(s.src) {
+			return s.errorf(s.off, "expected digit, found end of input")
+		}
+		return s.errorf(s.off, "expected digit, found %q", s.src[s.off])
+	}
+	n := 0
+	for s.off < len(s.src) && isDigit(s.src[s.off]) {
+		d := int(s.src[s.off] - '0')
+		if n > (math.MaxInt-d)/10 {
+			return s.errorf(start, "integer overflows int")
+		}
+		n = n*10 + d
+		s.off++
+	}
+	if !s.lenient && s.off < len(s.src) && !s.isSep(s.src[s.off]) {
+		return s.errorf(s.off, "unexpected %q after integer", s.src[s.off])
+	}
+	if neg {
+		n = -n
+	}
+	s.val = n
+	return true
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestInts(t *testing.T) {
+	tests := []struct {
+		input          string
+		unsigned, sign []int
+	}{
+		{"", nil, nil},
+		{"a1b-2c+3", []int{1, 2, 3}, []int{1, -2, 3}},
+		{"x=-12, y=+7\r\n", []int{12, 7}, []int{-12, 7}},
+		{"5 - 3", []int{5, 3}, []int{5, 3}},
+		{"--4", []int{4}, []int{-4}},
+		{"7-", []int{7}, []int{7}},
+		{"-", nil, nil},
+		{"1\r\n2\r\n", []int{1, 2}, []int{1, 2}},
+	}
+	for _, tt := range tests {
+		for _, signed := range []bool{false, true} {
+			want := tt.unsigned
+			if signed {
+				want = tt.sign
+			}
+			got, err := Ints([]byte(tt.input), signed)
+			if err != nil || !slices.Equal(got, want) {
+				t.Errorf("Ints(%q, %t) = %v, %v, want %v", tt.input, signed, got, err, want)
+			}
+		}
+	}
+}
+
+func TestIntsOverflow(t *testing.T) {
+	_, err := Ints([]byte("1\nab 99999999999999999999\n"), true)
+	var se *SyntaxError
+	if !errors.As(err, &se) {
+		t.Fatalf("Ints() = %v, want a *SyntaxError", err)
+	}
+	if se.Line != 2 || se.Col != 4 || se.Msg != "integer overflows int" {
+		t.Errorf("Ints() = %v, want line 2, col 4: integer overflows int", err)
+	}
+}
+
+func TestScanLine(t *testing.T) {
+	s := NewIntScanner([]byte("1 2\r\n\r\n3\t4"), " \t")
+	var got [][]int
+	for {
+		row, ok := s.ScanLine(nil)
+		if !ok {
+			break
+		}
+		got = append(got, row)
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	want := [][]int{{1, 2}, nil, {3, 4}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("ScanLine() rows = %v, want %v", got, want)
+	}
+	if line, col := s.Pos(); line != 3 || col != 3 {
+		t.Errorf("Pos() after the last integer = %d, %d, want 3, 3", line, col)
+	}
+}
+
+func TestStrictErrors(t *testing.T) {
+	tests := []struct {
+		input     string
+		signed    bool
+		line, col int
+		msg       string
+	}{
+		{"1 2\n3,4\n", false, 2, 2, `unexpected ',' after integer`},
+		{"1 2\r\n3 x\r\n", false, 2, 3, `expected digit, found 'x'`},
+		{"1 -2\n", false, 1, 3, `expected digit, found '-'`},
+		{"1 -x\n", true, 1, 4, `expected digit, found 'x'`},
+		{"1\n2 -", true, 2, 4, "expected digit, found end of input"},
+		{"12a\n", false, 1, 3, `unexpected 'a' after integer`},
+		{"1\n\n 99999999999999999999", false, 3, 2, "integer overflows int"},
+	}
+	for _, tt := range tests {
+		s := NewIntScanner([]byte(tt.input), " ")
+		s.Signed = tt.signed
+		for s.Next() {
+		}
+		var se *SyntaxError
+		if !errors.As(s.Err(), &se) {
+			t.Errorf("scanning %q: err = %v, want a *SyntaxError", tt.input, s.Err())
+			continue
+		}
+		if se.Line != tt.line || se.Col != tt.col || se.Msg != tt.msg {
+			t.Errorf("scanning %q: %v, want line %d, col %d: %s", tt.input, se, tt.line, tt.col, tt.msg)
+		}
+	}
+}
+
+func TestErrorf(t *testing.T) {
+	s := NewIntScanner([]byte("1\n  22 3\n"), " ")
+	for s.Next() && s.Int() != 22 {
+	}
+	err := s.Errorf("page %d is out of range", s.Int())
+	if got := err.Error(); got != "line 2, col 3: page 22 is out of range" {
+		t.Errorf("Errorf() = %q", got)
+	}
+}
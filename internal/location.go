@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Location is a point on a grid, or the offset between two points. Rows grow
+// downwards and columns to the right.
+type Location [2]int
+
+func (n Location) String() string {
+	return fmt.Sprintf("{ row: %d, col: %d }", n.Row(), n.Col())
+}
+
+func (n Location) Row() int {
+	return n[0]
+}
+
+func (n Location) Col() int {
+	return n[1]
+}
+
+func (n Location) Add(o Location) Location {
+	return Location{n[0] + o[0], n[1] + o[1]}
+}
+
+func (n Location) Sub(o Location) Location {
+	return Location{n[0] - o[0], n[1] - o[1]}
+}
+
+func (n Location) Scale(k int) Location {
+	return Location{n[0] * k, n[1] * k}
+}
+
+func (n Location) Neg() Location {
+	return Location{-n[0], -n[1]}
+}
+
+// Manhattan is the number of orthogonal steps between n and o.
+func (n Location) Manhattan(o Location) int {
+	d := n.Sub(o)
+	return abs(d[0]) + abs(d[1])
+}
+
+// Chebyshev is the number of king moves between n and o.
+func (n Location) Chebyshev(o Location) int {
+	d := n.Sub(o)
+	return max(abs(d[0]), abs(d[1]))
+}
+
+// RotateCW rotates n a quarter turn clockwise about the origin, so an offset
+// pointing up ends up pointing right.
+func (n Location) RotateCW() Location {
+	return Location{n[1], -n[0]}
+}
+
+// RotateCCW rotates n a quarter turn counter-clockwise about the origin.
+func (n Location) RotateCCW() Location {
+	return Location{-n[1], n[0]}
+}
+
+// Compare orders locations row-major: by row, then by column.
+func (n Location) Compare(o Location) int {
+	return cmp.Or(cmp.Compare(n[0], o[0]), cmp.Compare(n[1], o[1]))
+}
+
+func (n Location) Less(o Location) bool {
+	return n.Compare(o) < 0
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
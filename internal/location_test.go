@@ -0,0 +1,73 @@
+package internal
+
+import "testing"
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		in, cw, ccw Location
+	}{
+		{Location{-1, 0}, Location{0, 1}, Location{0, -1}}, // up turns right, or left
+		{Location{0, 1}, Location{1, 0}, Location{-1, 0}},
+		{Location{1, 0}, Location{0, -1}, Location{0, 1}},
+		{Location{0, -1}, Location{-1, 0}, Location{1, 0}},
+		{Location{2, 3}, Location{3, -2}, Location{-3, 2}},
+		{Location{0, 0}, Location{0, 0}, Location{0, 0}},
+	}
+	for _, tt := range tests {
+		if got := tt.in.RotateCW(); got != tt.cw {
+			t.Errorf("%v.RotateCW() = %v, want %v", tt.in, got, tt.cw)
+		}
+		if got := tt.in.RotateCCW(); got != tt.ccw {
+			t.Errorf("%v.RotateCCW() = %v, want %v", tt.in, got, tt.ccw)
+		}
+		if got := tt.in.RotateCW().RotateCCW(); got != tt.in {
+			t.Errorf("%v.RotateCW().RotateCCW() = %v", tt.in, got)
+		}
+		if got := tt.in.RotateCW().RotateCW().RotateCW().RotateCW(); got != tt.in {
+			t.Errorf("four RotateCW() of %v = %v", tt.in, got)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b Location
+		want int
+	}{
+		{Location{1, 1}, Location{1, 1}, 0},
+		{Location{0, 9}, Location{1, 0}, -1}, // rows first
+		{Location{2, 0}, Location{1, 9}, 1},
+		{Location{1, 2}, Location{1, 3}, -1}, // then columns
+		{Location{1, 3}, Location{1, 2}, 1},
+		{Location{-1, 0}, Location{0, -1}, -1},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Compare(tt.b); got != tt.want {
+			t.Errorf("%v.Compare(%v) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := tt.a.Less(tt.b); got != (tt.want < 0) {
+			t.Errorf("%v.Less(%v) = %t", tt.a, tt.b, got)
+		}
+	}
+}
+
+func TestDistances(t *testing.T) {
+	tests := []struct {
+		a, b                 Location
+		manhattan, chebyshev int
+	}{
+		{Location{0, 0}, Location{0, 0}, 0, 0},
+		{Location{0, 0}, Location{3, 4}, 7, 4},
+		{Location{3, 4}, Location{0, 0}, 7, 4},
+		{Location{-2, 5}, Location{1, -1}, 9, 6},
+		{Location{0, 0}, Location{-3, 3}, 6, 3},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Manhattan(tt.b); got != tt.manhattan {
+			t.Errorf("%v.Manhattan(%v) = %d, want %d", tt.a, tt.b, got, tt.manhattan)
+		}
+		if got := tt.a.Chebyshev(tt.b); got != tt.chebyshev {
+			t.Errorf("%v.Chebyshev(%v) = %d, want %d", tt.a, tt.b, got, tt.chebyshev)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package internal
+
+import "fmt"
+
+// Orientation is one of the eight ways to rotate and mirror a pattern.
+type Orientation uint8
+
+const (
+	Rot0 Orientation = iota
+	Rot90
+	Rot180
+	Rot270
+	// the mirrored orientations flip the pattern left to right before rotating it.
+	Mirror0
+	Mirror90
+	Mirror180
+	Mirror270
+)
+
+// Orientations holds every orientation, identity first.
+var Orientations = [...]Orientation{Rot0, Rot90, Rot180, Rot270, Mirror0, Mirror90, Mirror180, Mirror270}
+
+var orientationNames = [...]string{"rot0", "rot90", "rot180", "rot270", "mirror0", "mirror90", "mirror180", "mirror270"}
+
+func (o Orientation) String() string {
+	if int(o) >= len(orientationNames) {
+		return fmt.Sprintf("Orientation(%d)", uint8(o))
+	}
+	return orientationNames[o]
+}
+
+// Quarter turns are clockwise.
+func (o Orientation) quarterTurns() int {
+	return int(o) % 4
+}
+
+func (o Orientation) mirrored() bool {
+	return o >= Mirror0
+}
+
+type patternCell[T comparable] struct {
+	value    T
+	wildcard bool
+}
+
+// Pattern is a small rectangular template searched for inside a grid.
+// Wildcard cells match any value.
+type Pattern[T comparable] struct {
+	cells *Grid[patternCell[T]]
+}
+
+// NewPattern builds a pattern from a grid, treating every cell for which
+// wildcard returns true as matching anything.
+func NewPattern[T comparable](g *Grid[T], wildcard func(T) bool) *Pattern[T] {
+	cells := NewGrid[patternCell[T]](g.Width(), g.Height())
+	for i, v := range g.cells {
+		cells.cells[i] = patternCell[T]{v, wildcard(v)}
+	}
+	return &Pattern[T]{cells}
+}
+
+// ParsePattern reads a byte pattern one row per line, with wildcard standing
+// for any byte.
+func ParsePattern(input string, wildcard byte) (*Pattern[byte], error) {
+	g, err := ParseGrid([]byte(input))
+	if err != nil {
+		return nil, err
+	}
+	return NewPattern(g, func(b byte) bool { return b == wildcard }), nil
+}
+
+func (p *Pattern[T]) Width() int {
+	return p.cells.Width()
+}
+
+func (p *Pattern[T]) Height() int {
+	return p.cells.Height()
+}
+
+// Orient returns the pattern mirrored and rotated as o describes.
+func (p *Pattern[T]) Orient(o Orientation) *Pattern[T] {
+	src := p.cells
+	if o.mirrored() {
+		src = NewGrid[patternCell[T]](p.Width(), p.Height())
+		for r := range p.Height() {
+			for c := range p.Width() {
+				src.cells[r*p.Width()+c] = p.cells.cells[r*p.Width()+p.Width()-1-c]
+			}
+		}
+	}
+	for range o.quarterTurns() {
+		h, w := src.Height(), src.Width()
+		rotated := NewGrid[patternCell[T]](h, w)
+		for r := range w {
+			for c := range h {
+				rotated.cells[r*h+c] = src.cells[(h-1-c)*w+r]
+			}
+		}
+		src = rotated
+	}
+	return &Pattern[T]{src}
+}
+
+func (p *Pattern[T]) equal(o *Pattern[T]) bool {
+	if p.Width() != o.Width() || p.Height() != o.Height() {
+		return false
+	}
+	for i, c := range p.cells.cells {
+		if c != o.cells.cells[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Distinct returns the orientations that produce different patterns, so a
+// symmetric pattern is not reported twice for the same spot.
+func (p *Pattern[T]) Distinct() []Orientation {
+	seen := make([]*Pattern[T], 0, len(Orientations))
+	res := make([]Orientation, 0, len(Orientations))
+	for _, o := range Orientations {
+		oriented := p.Orient(o)
+		duplicate := false
+		for _, s := range seen {
+			if s.equal(oriented) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			seen = append(seen, oriented)
+			res = append(res, o)
+		}
+	}
+	return res
+}
+
+// MatchAt reports whether the pattern, as is, matches g with its top-left
+// corner at p.
+func (p *Pattern[T]) MatchAt(g *Grid[T], at Location) bool {
+	if !g.InBounds(at) || !g.InBounds(at.Add(Location{p.Height() - 1, p.Width() - 1})) {
+		return false
+	}
+	for i, c := range p.cells.cells {
+		if c.wildcard {
+			continue
+		}
+		r, col := at.Row()+i/p.Width(), at.Col()+i%p.Width()
+		if g.cells[r*g.width+col] != c.value {
+			return false
+		}
+	}
+	return true
+}
+
+// PatternMatch is a place a pattern was found and the orientation it was
+// found in. At is the top-left corner of the oriented pattern.
+type PatternMatch struct {
+	At          Location
+	Orientation Orientation
+}
+
+// FindPattern searches g for every distinct orientation of p, returning the
+// matches ordered by orientation and then row-major position.
+func FindPattern[T comparable](g *Grid[T], p *Pattern[T]) []PatternMatch {
+	res := make([]PatternMatch, 0, 16)
+	for _, o := range p.Distinct() {
+		oriented := p.Orient(o)
+		for r := 0; r+oriented.Height() <= g.Height(); r++ {
+			for c := 0; c+oriented.Width() <= g.Width(); c++ {
+				if oriented.MatchAt(g, Location{r, c}) {
+					res = append(res, PatternMatch{Location{r, c}, o})
+				}
+			}
+		}
+	}
+	return res
+}
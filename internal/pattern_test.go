@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// patternString renders a byte pattern one row per line.
+func patternString(p *Pattern[byte]) string {
+	var b strings.Builder
+	for r := range p.Height() {
+		if r > 0 {
+			b.WriteByte('\n')
+		}
+		for c := range p.Width() {
+			b.WriteByte(p.cells.cells[r*p.Width()+c].value)
+		}
+	}
+	return b.String()
+}
+
+func TestOrient(t *testing.T) {
+	p, err := ParsePattern("ab\n.d\nef", '.')
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[Orientation]string{
+		Rot0:      "ab\n.d\nef",
+		Rot90:     "e.a\nfdb",
+		Rot180:    "fe\nd.\nba",
+		Rot270:    "bdf\na.e",
+		Mirror0:   "ba\nd.\nfe",
+		Mirror90:  "fdb\ne.a",
+		Mirror180: "ef\n.d\nab",
+		Mirror270: "a.e\nbdf",
+	}
+	for _, o := range Orientations {
+		oriented := p.Orient(o)
+		if got := patternString(oriented); got != want[o] {
+			t.Errorf("Orient(%v) =\n%s\nwant\n%s", o, got, want[o])
+		}
+		// the wildcard travels with its cell.
+		for i, c := range oriented.cells.cells {
+			if c.wildcard != (c.value == '.') {
+				t.Errorf("Orient(%v) cell %d is %q, wildcard %t", o, i, c.value, c.wildcard)
+			}
+		}
+	}
+	if got := p.Distinct(); !slices.Equal(got, Orientations[:]) {
+		t.Errorf("Distinct() = %v, want all eight", got)
+	}
+}
+
+func TestFindPattern(t *testing.T) {
+	cross, err := ParsePattern("M.S\n.A.\nM.S", '.')
+	if err != nil {
+		t.Fatal(err)
+	}
+	// mirroring the cross gives one of its rotations.
+	if got, want := cross.Distinct(), []Orientation{Rot0, Rot90, Rot180, Rot270}; !slices.Equal(got, want) {
+		t.Errorf("Distinct() = %v, want %v", got, want)
+	}
+	chain, err := ParsePattern("ab\n.d\nef", '.')
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		pattern *Pattern[byte]
+		grid    string
+		want    []PatternMatch
+	}{
+		{
+			name:    "symmetric pattern found once per spot",
+			pattern: cross,
+			grid: `M.S.....
+.A...S.S
+M.S...A.
+.....M.M`,
+			want: []PatternMatch{{Location{0, 0}, Rot0}, {Location{1, 5}, Rot270}},
+		},
+		{
+			name:    "At is the corner of the oriented pattern",
+			pattern: chain,
+			grid: `......
+..exa.
+..fdb.`,
+			want: []PatternMatch{{Location{1, 2}, Rot90}},
+		},
+		{
+			name:    "ordered by orientation, then position",
+			pattern: chain,
+			grid: `fe.ab
+dxxxd
+baxef`,
+			want: []PatternMatch{{Location{0, 3}, Rot0}, {Location{0, 0}, Rot180}},
+		},
+		{
+			name:    "pattern larger than the grid",
+			pattern: cross,
+			grid:    "MAS\nMAS",
+			want:    []PatternMatch{},
+		},
+	}
+	for _, tt := range tests {
+		g, err := ParseGrid([]byte(tt.grid))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := FindPattern(g, tt.pattern)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: FindPattern() = %v, want %v", tt.name, got, tt.want)
+		}
+		for _, m := range got {
+			if !tt.pattern.Orient(m.Orientation).MatchAt(g, m.At) {
+				t.Errorf("%s: %v does not match at %v", tt.name, m.Orientation, m.At)
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"time"
+)
+
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// sampleInterval is how often the live heap is polled while a phase runs.
+const sampleInterval = 200 * time.Microsecond
+
+// Stats is the cost of a single phase of a solver.
+type Stats struct {
+	Duration   time.Duration
+	Allocs     uint64 // heap objects allocated
+	AllocBytes uint64 // heap bytes allocated
+	PeakHeap   uint64 // largest live heap observed, in bytes
+}
+
+// Measure runs f and reports its wall time and heap usage. A garbage
+// collection is forced beforehand so phases do not pay for each other.
+func Measure(f func()) Stats {
+	runtime.GC()
+	heap := []metrics.Sample{{Name: heapObjectsMetric}}
+
+	// everything the sampler needs is set up before the baseline is read, so
+	// its own allocations are not charged to f.
+	var peak uint64
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	ticker := time.NewTicker(sampleInterval)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			metrics.Read(heap)
+			peak = max(peak, heap[0].Value.Uint64())
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	// ReadMemStats flushes the per-P allocation caches, which the
+	// runtime/metrics allocation counters only catch up with lazily.
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	begin := time.Now()
+	f()
+	elapsed := time.Since(begin)
+	runtime.ReadMemStats(&after)
+	close(done)
+	wg.Wait()
+	ticker.Stop()
+
+	metrics.Read(heap)
+	return Stats{
+		Duration:   elapsed,
+		Allocs:     after.Mallocs - before.Mallocs,
+		AllocBytes: after.TotalAlloc - before.TotalAlloc,
+		PeakHeap:   max(peak, heap[0].Value.Uint64()),
+	}
+}
+
+// PartResult is the outcome of solving one part.
+type PartResult struct {
+	Part   int
+	Answer Answer
+	Err    error
+	Stats  Stats
+}
+
+// Result is the outcome of running a day against one input.
+type Result struct {
+	Day       int
+	InputHash string
+	Parse     Stats
+	ParseErr  error
+	Parts     []PartResult
+}
+
+// Run parses input once and solves each of the given parts, measuring every
+// phase separately. Parts are skipped when parsing fails.
+func (d *Day) Run(input []byte, parts ...int) Result {
+	res := Result{Day: d.Number, InputHash: HashInput(input)}
+	var parsed any
+	res.Parse = Measure(func() { parsed, res.ParseErr = d.Parse(input) })
+	if res.ParseErr != nil {
+		return res
+	}
+	for _, p := range parts {
+		pr := PartResult{Part: p}
+		pr.Stats = Measure(func() { pr.Answer, pr.Err = d.Solve(p, parsed) })
+		res.Parts = append(res.Parts, pr)
+	}
+	return res
+}
@@ -0,0 +1,33 @@
+package internal
+
+import "testing"
+
+var sink [][]byte
+
+func TestMeasureCountsAllocations(t *testing.T) {
+	stats := Measure(func() {
+		for range 10 {
+			sink = append(sink, make([]byte, 64))
+		}
+	})
+	sink = nil
+	// the ten buffers plus the growth of sink itself.
+	if stats.Allocs < 10 || stats.Allocs > 16 {
+		t.Errorf("Allocs = %d, want the 10 buffers and a few slice growths", stats.Allocs)
+	}
+	if stats.AllocBytes < 10*64 {
+		t.Errorf("AllocBytes = %d, want at least %d", stats.AllocBytes, 10*64)
+	}
+}
+
+func TestMeasureNoAllocations(t *testing.T) {
+	n := 0
+	stats := Measure(func() {
+		for i := range 1000 {
+			n += i
+		}
+	})
+	if stats.Allocs != 0 || stats.AllocBytes != 0 {
+		t.Errorf("Measure() of a loop = %d allocs, %d bytes, want none", stats.Allocs, stats.AllocBytes)
+	}
+}
@@ -0,0 +1,71 @@
+package internal
+
+import "iter"
+
+// Cells yields every location and value in row-major order.
+func (g *Grid[T]) Cells() iter.Seq2[Location, T] {
+	return func(yield func(Location, T) bool) {
+		for i, v := range g.cells {
+			if !yield(Location{i / g.width, i % g.width}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Ray yields the locations and values from start, moving by delta each step,
+// until it leaves the grid. A zero delta yields start once.
+func (g *Grid[T]) Ray(start, delta Location) iter.Seq2[Location, T] {
+	return func(yield func(Location, T) bool) {
+		for p := start; g.InBounds(p); p = p.Add(delta) {
+			if !yield(p, g.cells[p.Row()*g.width+p.Col()]) || delta == (Location{}) {
+				return
+			}
+		}
+	}
+}
+
+// RowCells yields row r from left to right.
+func (g *Grid[T]) RowCells(r int) iter.Seq2[Location, T] {
+	return g.Ray(Location{r, 0}, Location{0, 1})
+}
+
+// ColCells yields column c from top to bottom.
+func (g *Grid[T]) ColCells(c int) iter.Seq2[Location, T] {
+	return g.Ray(Location{0, c}, Location{1, 0})
+}
+
+// Diagonal yields the top-left to bottom-right diagonal passing through p,
+// starting from its top-left end.
+func (g *Grid[T]) Diagonal(p Location) iter.Seq2[Location, T] {
+	if !g.InBounds(p) {
+		return empty[T]
+	}
+	back := min(p.Row(), p.Col())
+	return g.Ray(p.Sub(Location{back, back}), Location{1, 1})
+}
+
+// AntiDiagonal yields the top-right to bottom-left diagonal passing through
+// p, starting from its top-right end.
+func (g *Grid[T]) AntiDiagonal(p Location) iter.Seq2[Location, T] {
+	if !g.InBounds(p) {
+		return empty[T]
+	}
+	back := min(p.Row(), g.width-1-p.Col())
+	return g.Ray(p.Add(Location{-back, back}), Location{1, -1})
+}
+
+func empty[T any](func(Location, T) bool) {}
+
+// MatchRay reports whether the values along the ray from start spell out
+// want. It stops reading at the first mismatch.
+func MatchRay[T comparable](g *Grid[T], start, delta Location, want []T) bool {
+	i := 0
+	for _, v := range g.Ray(start, delta) {
+		if i == len(want) || v != want[i] {
+			break
+		}
+		i++
+	}
+	return i == len(want)
+}
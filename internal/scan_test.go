@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"iter"
+	"testing"
+)
+
+// scanGrid is
+//
+//	abcd
+//	efgh
+//	ijkl
+func scanGrid(t *testing.T) *Grid[byte] {
+	t.Helper()
+	g, err := ParseGrid([]byte("abcd\nefgh\nijkl\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func collectScan(seq iter.Seq2[Location, byte]) (string, []Location) {
+	var values []byte
+	var locs []Location
+	for p, v := range seq {
+		values = append(values, v)
+		locs = append(locs, p)
+	}
+	return string(values), locs
+}
+
+func TestScans(t *testing.T) {
+	g := scanGrid(t)
+	tests := []struct {
+		name  string
+		seq   iter.Seq2[Location, byte]
+		want  string
+		first Location
+	}{
+		{"row", g.RowCells(1), "efgh", Location{1, 0}},
+		{"col", g.ColCells(3), "dhl", Location{0, 3}},
+		{"ray", g.Ray(Location{2, 3}, Location{-1, -1}), "lgb", Location{2, 3}},
+		{"zero delta ray", g.Ray(Location{1, 1}, Location{}), "f", Location{1, 1}},
+		{"ray from outside", g.Ray(Location{-1, 0}, Location{1, 0}), "", Location{}},
+		{"diagonal", g.Diagonal(Location{1, 2}), "bgl", Location{0, 1}},
+		{"diagonal from bottom left", g.Diagonal(Location{2, 0}), "i", Location{2, 0}},
+		{"diagonal from top right", g.Diagonal(Location{0, 3}), "d", Location{0, 3}},
+		{"long diagonal", g.Diagonal(Location{2, 2}), "afk", Location{0, 0}},
+		{"anti-diagonal", g.AntiDiagonal(Location{1, 2}), "dgj", Location{0, 3}},
+		{"anti-diagonal from bottom left", g.AntiDiagonal(Location{2, 0}), "cfi", Location{0, 2}},
+		{"anti-diagonal from top left", g.AntiDiagonal(Location{0, 0}), "a", Location{0, 0}},
+		{"anti-diagonal from bottom right", g.AntiDiagonal(Location{2, 3}), "l", Location{2, 3}},
+		{"diagonal outside", g.Diagonal(Location{3, 0}), "", Location{}},
+		{"anti-diagonal outside", g.AntiDiagonal(Location{0, 4}), "", Location{}},
+	}
+	for _, tt := range tests {
+		got, locs := collectScan(tt.seq)
+		if got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+			continue
+		}
+		if len(locs) > 0 && locs[0] != tt.first {
+			t.Errorf("%s starts at %v, want %v", tt.name, locs[0], tt.first)
+		}
+		for i, p := range locs {
+			if v, _ := g.Get(p); v != got[i] {
+				t.Errorf("%s yielded %q at %v, which holds %q", tt.name, got[i], p, v)
+			}
+		}
+	}
+}
+
+func TestScanStopsEarly(t *testing.T) {
+	g := scanGrid(t)
+	n := 0
+	for range g.Ray(Location{0, 0}, Location{0, 1}) {
+		n++
+		if n == 2 {
+			break
+		}
+	}
+	if n != 2 {
+		t.Errorf("broke out after %d values, want 2", n)
+	}
+}
+
+func TestMatchRay(t *testing.T) {
+	g := scanGrid(t)
+	tests := []struct {
+		start, delta Location
+		want         string
+		match        bool
+	}{
+		{Location{0, 0}, Location{0, 1}, "abcd", true},
+		{Location{0, 0}, Location{0, 1}, "ab", true},
+		{Location{1, 2}, Location{1, 1}, "gl", true},
+		{Location{1, 2}, Location{1, 1}, "glx", false},
+		{Location{2, 3}, Location{0, -1}, "lkjx", false},
+		{Location{0, 0}, Location{1, 0}, "aex", false},
+		{Location{1, 1}, Location{}, "f", true},
+		{Location{1, 1}, Location{}, "ff", false},
+		{Location{0, 0}, Location{0, 1}, "", true},
+		{Location{5, 5}, Location{0, 1}, "a", false},
+	}
+	for _, tt := range tests {
+		if got := MatchRay(g, tt.start, tt.delta, []byte(tt.want)); got != tt.match {
+			t.Errorf("MatchRay(%v, %v, %q) = %v, want %v", tt.start, tt.delta, tt.want, got, tt.match)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Package search finds paths through graphs that are described by a
+// neighbor function rather than stored, such as grids or puzzle states.
+// States can be any comparable type.
+package search
+
+import (
+	"container/heap"
+	"iter"
+	"slices"
+
+	"github.com/jdpolicano/aof-go/internal"
+	"github.com/jdpolicano/aof-go/internal/direction"
+)
+
+// Neighbors yields the states one step away from a state.
+type Neighbors[S comparable] func(S) iter.Seq[S]
+
+// Weighted yields the states one step away from a state and the cost of
+// that step. Costs must not be negative.
+type Weighted[S comparable] func(S) iter.Seq2[S, int]
+
+// Unweighted gives every step of next a cost of 1.
+func Unweighted[S comparable](next Neighbors[S]) Weighted[S] {
+	return func(s S) iter.Seq2[S, int] {
+		return func(yield func(S, int) bool) {
+			for n := range next(s) {
+				if !yield(n, 1) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Tree is the result of searching outwards from one or more starts: the
+// cheapest cost to every reached state and every way of getting there at
+// that cost.
+type Tree[S comparable] struct {
+	dist    map[S]int
+	parents map[S][]S
+	order   []S
+}
+
+func newTree[S comparable]() *Tree[S] {
+	return &Tree[S]{dist: make(map[S]int), parents: make(map[S][]S)}
+}
+
+// Dist is the cost of the cheapest path to s, false if s was not reached.
+func (t *Tree[S]) Dist(s S) (int, bool) {
+	d, ok := t.dist[s]
+	return d, ok
+}
+
+// Reached returns every reached state, nearest first.
+func (t *Tree[S]) Reached() []S {
+	return slices.Clone(t.order)
+}
+
+// Path returns one cheapest path from a start to s, both ends included, or
+// nil if s was not reached.
+func (t *Tree[S]) Path(s S) []S {
+	if _, ok := t.dist[s]; !ok {
+		return nil
+	}
+	path := []S{s}
+	for ps := t.parents[s]; len(ps) > 0; ps = t.parents[ps[0]] {
+		path = append(path, ps[0])
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// AllPaths yields every cheapest path from a start to s, both ends
+// included. Each path is a fresh slice. Paths that pass through a start or
+// visit a state twice over zero-cost steps are left out, so there are
+// always finitely many.
+func (t *Tree[S]) AllPaths(s S) iter.Seq[[]S] {
+	return func(yield func([]S) bool) {
+		if _, ok := t.dist[s]; !ok {
+			return
+		}
+		// walk back through the parents, building each path end first.
+		rev := []S{s}
+		onPath := internal.NewSet(s)
+		var walk func() bool
+		walk = func() bool {
+			ps := t.parents[rev[len(rev)-1]]
+			if len(ps) == 0 {
+				path := slices.Clone(rev)
+				slices.Reverse(path)
+				return yield(path)
+			}
+			for _, p := range ps {
+				if !onPath.Add(p) {
+					continue
+				}
+				rev = append(rev, p)
+				if !walk() {
+					return false
+				}
+				rev = rev[:len(rev)-1]
+				onPath.Remove(p)
+			}
+			return true
+		}
+		walk()
+	}
+}
+
+// BFS searches outwards from starts one step at a time, reaching every
+// state reachable from them.
+func BFS[S comparable](next Neighbors[S], starts ...S) *Tree[S] {
+	t := newTree[S]()
+	queue := make([]S, 0, len(starts))
+	for _, s := range starts {
+		if _, seen := t.dist[s]; !seen {
+			t.dist[s] = 0
+			t.order = append(t.order, s)
+			queue = append(queue, s)
+		}
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		d := t.dist[cur] + 1
+		for n := range next(cur) {
+			nd, seen := t.dist[n]
+			switch {
+			case !seen:
+				t.dist[n] = d
+				t.parents[n] = []S{cur}
+				t.order = append(t.order, n)
+				queue = append(queue, n)
+			case nd == d:
+				t.parents[n] = append(t.parents[n], cur)
+			}
+		}
+	}
+	return t
+}
+
+// DFS yields every state reachable from start, depth first, each once.
+// Neighbors are explored in the order next yields them.
+func DFS[S comparable](next Neighbors[S], start S) iter.Seq[S] {
+	return func(yield func(S) bool) {
+		seen := internal.NewSet[S]()
+		var visit func(s S) bool
+		visit = func(s S) bool {
+			if !seen.Add(s) {
+				return true
+			}
+			if !yield(s) {
+				return false
+			}
+			for n := range next(s) {
+				if !visit(n) {
+					return false
+				}
+			}
+			return true
+		}
+		visit(start)
+	}
+}
+
+// Dijkstra finds the cheapest cost from starts to every reachable state.
+func Dijkstra[S comparable](next Weighted[S], starts ...S) *Tree[S] {
+	t := newTree[S]()
+	search(t, next, func(S) int { return 0 }, func(S) bool { return false }, starts)
+	return t
+}
+
+// AStar finds the cheapest path from start to any state for which goal is
+// true, guided by h, an estimate of the remaining cost that must never
+// overestimate it. The heuristic does not need to be consistent: a state
+// reached again more cheaply after it was expanded is expanded again. It
+// returns the path, both ends included, and its cost.
+func AStar[S comparable](next Weighted[S], start S, goal func(S) bool, h func(S) int) ([]S, int, bool) {
+	t := newTree[S]()
+	end, ok := search(t, next, h, goal, []S{start})
+	if !ok {
+		return nil, 0, false
+	}
+	return t.Path(end), t.dist[end], true
+}
+
+// search is Dijkstra ordered by cost plus h, stopping at the first expanded
+// state that satisfies goal.
+func search[S comparable](t *Tree[S], next Weighted[S], h func(S) int, goal func(S) bool, starts []S) (S, bool) {
+	pq := make(queue[S], 0, 64)
+	isStart := internal.NewSet[S]()
+	for _, s := range starts {
+		if isStart.Add(s) {
+			t.dist[s] = 0
+			heap.Push(&pq, item[S]{s, 0, h(s)})
+		}
+	}
+	// expanded holds the states whose neighbors were relaxed at their current
+	// cost; reached keeps Reached free of states expanded twice.
+	expanded, reached := internal.NewSet[S](), internal.NewSet[S]()
+	for pq.Len() > 0 {
+		cur := heap.Pop(&pq).(item[S])
+		if cur.cost > t.dist[cur.state] || !expanded.Add(cur.state) {
+			continue
+		}
+		if reached.Add(cur.state) {
+			t.order = append(t.order, cur.state)
+		}
+		if goal(cur.state) {
+			return cur.state, true
+		}
+		for n, w := range next(cur.state) {
+			nd := cur.cost + w
+			old, seen := t.dist[n]
+			switch {
+			case !seen || nd < old:
+				t.dist[n] = nd
+				t.parents[n] = []S{cur.state}
+				expanded.Remove(n)
+				heap.Push(&pq, item[S]{n, nd, nd + h(n)})
+			case nd == old && !isStart.Has(n):
+				// zero-cost steps can make the parents loop, which AllPaths
+				// steps around; starts keep no parents so every walk ends.
+				t.parents[n] = append(t.parents[n], cur.state)
+			}
+		}
+	}
+	var zero S
+	return zero, false
+}
+
+type item[S comparable] struct {
+	state    S
+	cost     int
+	priority int
+}
+
+type queue[S comparable] []item[S]
+
+func (q queue[S]) Len() int           { return len(q) }
+func (q queue[S]) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q queue[S]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *queue[S]) Push(x any)        { *q = append(*q, x.(item[S])) }
+func (q *queue[S]) Pop() any {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
+
+// GridNeighbors steps between the four orthogonally adjacent cells of g,
+// keeping only those for which passable is true.
+func GridNeighbors[T any](g *internal.Grid[T], passable func(internal.Location, T) bool) Neighbors[internal.Location] {
+	return func(p internal.Location) iter.Seq[internal.Location] {
+		return func(yield func(internal.Location) bool) {
+			for _, d := range direction.Cardinal {
+				n := d.Step(p)
+				if v, ok := g.Get(n); ok && passable(n, v) && !yield(n) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Manhattan is an A* heuristic for grids where every step costs at least 1.
+func Manhattan(goal internal.Location) func(internal.Location) int {
+	return func(p internal.Location) int {
+		return p.Manhattan(goal)
+	}
+}
@@ -0,0 +1,279 @@
+package search
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/jdpolicano/aof-go/internal"
+)
+
+type L = internal.Location
+
+func maze(t *testing.T, rows string) (*internal.Grid[byte], Neighbors[L]) {
+	t.Helper()
+	g, err := internal.ParseGrid([]byte(rows))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g, GridNeighbors(g, func(_ L, b byte) bool { return b != '#' })
+}
+
+// edges is a weighted graph written out by hand. Neighbors are yielded in
+// ascending order so results do not depend on map iteration.
+type edges map[string]map[string]int
+
+func (e edges) next(s string) iter.Seq2[string, int] {
+	return func(yield func(string, int) bool) {
+		for _, n := range slices.Sorted(maps.Keys(e[s])) {
+			if !yield(n, e[s][n]) {
+				return
+			}
+		}
+	}
+}
+
+func TestBFS(t *testing.T) {
+	_, next := maze(t, `
+..#.
+.##.
+....
+#..#`)
+	tree := BFS(next, L{0, 0})
+	for p, want := range map[L]int{{0, 0}: 0, {0, 1}: 1, {2, 0}: 2, {2, 3}: 5, {0, 3}: 7, {3, 2}: 5} {
+		if got, ok := tree.Dist(p); !ok || got != want {
+			t.Errorf("Dist(%v) = %d, %v, want %d", p, got, ok, want)
+		}
+	}
+	if _, ok := tree.Dist(L{0, 2}); ok {
+		t.Error("reached a wall")
+	}
+	want := []L{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {2, 2}, {2, 3}, {1, 3}, {0, 3}}
+	if got := tree.Path(L{0, 3}); !slices.Equal(got, want) {
+		t.Errorf("Path({0, 3}) = %v, want %v", got, want)
+	}
+	if got := tree.Path(L{0, 0}); !slices.Equal(got, []L{{0, 0}}) {
+		t.Errorf("Path(start) = %v, want just the start", got)
+	}
+	reached := tree.Reached()
+	if len(reached) != 11 || reached[0] != (L{0, 0}) {
+		t.Errorf("Reached() = %v, want the 11 open cells from the start", reached)
+	}
+	for i := 1; i < len(reached); i++ {
+		a, _ := tree.Dist(reached[i-1])
+		b, _ := tree.Dist(reached[i])
+		if a > b {
+			t.Errorf("Reached() is not nearest first: %v before %v", reached[i-1], reached[i])
+		}
+	}
+}
+
+func TestBFSMultipleStarts(t *testing.T) {
+	_, next := maze(t, `
+.....
+.....`)
+	tree := BFS(next, L{0, 0}, L{1, 4}, L{0, 0})
+	for p, want := range map[L]int{{0, 0}: 0, {1, 4}: 0, {0, 2}: 2, {1, 2}: 2, {0, 3}: 2, {1, 1}: 2} {
+		if got, _ := tree.Dist(p); got != want {
+			t.Errorf("Dist(%v) = %d, want %d", p, got, want)
+		}
+	}
+	if got := tree.Path(L{0, 3}); got[0] != (L{1, 4}) || len(got) != 3 {
+		t.Errorf("Path({0, 3}) = %v, want 3 steps from {1, 4}", got)
+	}
+}
+
+func TestUnreachable(t *testing.T) {
+	_, next := maze(t, `
+.#.
+.#.`)
+	tree := BFS(next, L{0, 0})
+	if _, ok := tree.Dist(L{0, 2}); ok {
+		t.Error("Dist() reached the far side of the wall")
+	}
+	if got := tree.Path(L{0, 2}); got != nil {
+		t.Errorf("Path() = %v, want nil", got)
+	}
+	for p := range tree.AllPaths(L{0, 2}) {
+		t.Errorf("AllPaths() yielded %v", p)
+	}
+	goal := L{1, 2}
+	if path, cost, ok := AStar(Unweighted(next), L{0, 0}, func(p L) bool { return p == goal }, Manhattan(goal)); ok {
+		t.Errorf("AStar() = %v, %d, want no path", path, cost)
+	}
+}
+
+func TestAllPaths(t *testing.T) {
+	_, next := maze(t, `
+...
+...
+...`)
+	corner := L{2, 2}
+	for name, tree := range map[string]*Tree[L]{
+		"BFS":      BFS(next, L{0, 0}),
+		"Dijkstra": Dijkstra(Unweighted(next), L{0, 0}),
+	} {
+		paths := slices.Collect(tree.AllPaths(corner))
+		// choosing 2 of the 4 steps to go down.
+		if len(paths) != 6 {
+			t.Errorf("%s: AllPaths() found %d paths, want 6", name, len(paths))
+		}
+		seen := make(map[string]bool)
+		for _, p := range paths {
+			if len(p) != 5 || p[0] != (L{0, 0}) || p[4] != corner {
+				t.Errorf("%s: AllPaths() yielded %v", name, p)
+			}
+			key := ""
+			for _, l := range p {
+				key += l.String()
+			}
+			if seen[key] {
+				t.Errorf("%s: AllPaths() yielded %v twice", name, p)
+			}
+			seen[key] = true
+		}
+		n := 0
+		for range tree.AllPaths(corner) {
+			n++
+			break
+		}
+		if n != 1 {
+			t.Errorf("%s: AllPaths() kept going after break", name)
+		}
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	g := edges{
+		"a": {"b": 1, "c": 4, "x": 2},
+		"b": {"c": 2, "d": 5},
+		"c": {"d": 1},
+		"x": {"d": 2},
+	}
+	tree := Dijkstra(g.next, "a")
+	for s, want := range map[string]int{"a": 0, "b": 1, "x": 2, "c": 3, "d": 4} {
+		if got, _ := tree.Dist(s); got != want {
+			t.Errorf("Dist(%s) = %d, want %d", s, got, want)
+		}
+	}
+	if got, want := tree.Reached(), []string{"a", "b", "x", "c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("Reached() = %v, want %v", got, want)
+	}
+	var got [][]string
+	for p := range tree.AllPaths("d") {
+		got = append(got, p)
+	}
+	want := [][]string{{"a", "x", "d"}, {"a", "b", "c", "d"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("AllPaths(d) = %v, want %v", got, want)
+	}
+
+	multi := Dijkstra(g.next, "c", "x")
+	if d, _ := multi.Dist("d"); d != 1 {
+		t.Errorf("Dist(d) from c and x = %d, want 1", d)
+	}
+	if _, ok := multi.Dist("a"); ok {
+		t.Error("reached a, which has no incoming edges")
+	}
+}
+
+func TestZeroCost(t *testing.T) {
+	g := edges{
+		"0": {"1": 0},
+		"1": {"0": 0, "2": 1, "3": 0},
+		"3": {"1": 0, "2": 1},
+	}
+	tree := Dijkstra(g.next, "0")
+	if got, want := tree.Path("1"), []string{"0", "1"}; !slices.Equal(got, want) {
+		t.Errorf("Path(1) = %v, want %v", got, want)
+	}
+	if got, want := tree.Path("0"), []string{"0"}; !slices.Equal(got, want) {
+		t.Errorf("Path(0) = %v, want %v", got, want)
+	}
+	if d, _ := tree.Dist("2"); d != 1 {
+		t.Errorf("Dist(2) = %d, want 1", d)
+	}
+	var got [][]string
+	for p := range tree.AllPaths("2") {
+		got = append(got, p)
+	}
+	want := [][]string{{"0", "1", "2"}, {"0", "1", "3", "2"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("AllPaths(2) = %v, want %v", got, want)
+	}
+
+	path, cost, ok := AStar(g.next, "0", func(s string) bool { return s == "1" }, func(string) int { return 0 })
+	if !ok || cost != 0 || !slices.Equal(path, []string{"0", "1"}) {
+		t.Errorf("AStar() = %v, %d, %v, want [0 1] at no cost", path, cost, ok)
+	}
+
+	// a zero-cost step into an already expanded state is still a cheapest way in.
+	g = edges{
+		"s": {"a": 1, "b": 1},
+		"b": {"a": 0},
+	}
+	got = nil
+	for p := range Dijkstra(g.next, "s").AllPaths("a") {
+		got = append(got, p)
+	}
+	want = [][]string{{"s", "a"}, {"s", "b", "a"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("AllPaths(a) = %v, want %v", got, want)
+	}
+}
+
+func TestAStar(t *testing.T) {
+	_, next := maze(t, `
+.....
+.###.
+...#.
+.#...`)
+	start, goal := L{2, 0}, L{2, 2}
+	path, cost, ok := AStar(Unweighted(next), start, func(p L) bool { return p == goal }, Manhattan(goal))
+	if !ok || cost != 2 || len(path) != 3 || path[0] != start || path[2] != goal {
+		t.Errorf("AStar() = %v, %d, %v, want the 2 step path", path, cost, ok)
+	}
+	// the straight line is walled off, and the bottom way round beats the top.
+	goal = L{2, 4}
+	path, cost, ok = AStar(Unweighted(next), start, func(p L) bool { return p == goal }, Manhattan(goal))
+	want := []L{{2, 0}, {2, 1}, {2, 2}, {3, 2}, {3, 3}, {3, 4}, {2, 4}}
+	if !ok || cost != 6 || !slices.Equal(path, want) {
+		t.Errorf("AStar() = %v, %d, %v, want %v at cost 6", path, cost, ok, want)
+	}
+}
+
+func TestAStarInconsistentHeuristic(t *testing.T) {
+	// h is admissible but not consistent: it makes s-b-c look better than
+	// s-a-c, so c is expanded at cost 3 before a finds it at cost 2.
+	g := edges{
+		"s": {"a": 1, "b": 2},
+		"a": {"c": 1},
+		"b": {"c": 1},
+		"c": {"g": 3},
+	}
+	h := map[string]int{"a": 4}
+	path, cost, ok := AStar(g.next, "s", func(s string) bool { return s == "g" }, func(s string) int { return h[s] })
+	if want := []string{"s", "a", "c", "g"}; !ok || cost != 5 || !slices.Equal(path, want) {
+		t.Errorf("AStar() = %v, %d, %v, want %v at cost 5", path, cost, ok, want)
+	}
+}
+
+func TestDFS(t *testing.T) {
+	g := edges{"a": {"b": 1, "c": 1}, "b": {"d": 1}, "c": {"a": 1, "d": 1}}
+	next := func(s string) iter.Seq[string] {
+		return func(yield func(string) bool) {
+			for n := range g.next(s) {
+				if !yield(n) {
+					return
+				}
+			}
+		}
+	}
+	if got, want := slices.Collect(DFS(next, "a")), []string{"a", "b", "d", "c"}; !slices.Equal(got, want) {
+		t.Errorf("DFS() = %v, want %v", got, want)
+	}
+	if got := slices.Collect(internal.Take(DFS(next, "a"), 2)); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("DFS() stopped early = %v", got)
+	}
+}
@@ -0,0 +1,178 @@
+package internal
+
+import (
+	"iter"
+	"slices"
+)
+
+// Lazy counterparts of MapSlice and FilterSlice. Each combinator pulls from
+// its source one value at a time, so a pipeline never builds intermediate
+// slices unless it ends in Collect.
+
+func Map[T any, U any](seq iter.Seq[T], f func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+func Filter[T any](seq iter.Seq[T], f func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if f(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func Reduce[T any, A any](seq iter.Seq[T], init A, f func(A, T) A) A {
+	acc := init
+	for v := range seq {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// Zip pairs up the values of a and b, stopping when either runs out.
+func Zip[T any, U any](a iter.Seq[T], b iter.Seq[U]) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+		for x := range a {
+			y, ok := next()
+			if !ok || !yield(x, y) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate pairs every value with its zero-based position.
+func Enumerate[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range seq {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Windows yields every run of n consecutive values. The slice is reused
+// between iterations, so clone it to keep it.
+func Windows[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if n <= 0 {
+			return
+		}
+		buf := make([]T, 0, n)
+		for v := range seq {
+			if len(buf) == n {
+				copy(buf, buf[1:])
+				buf = buf[:n-1]
+			}
+			buf = append(buf, v)
+			if len(buf) == n && !yield(buf) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk yields consecutive groups of n values, the last one possibly
+// shorter. The slice is reused between iterations, so clone it to keep it.
+func Chunk[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if n <= 0 {
+			return
+		}
+		buf := make([]T, 0, n)
+		for v := range seq {
+			buf = append(buf, v)
+			if len(buf) == n {
+				if !yield(buf) {
+					return
+				}
+				buf = buf[:0]
+			}
+		}
+		if len(buf) > 0 {
+			yield(buf)
+		}
+	}
+}
+
+// Take yields at most the first n values.
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			i++
+			if i == n {
+				return
+			}
+		}
+	}
+}
+
+// Skip drops the first n values.
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		for v := range seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func Count[T any](seq iter.Seq[T]) int {
+	n := 0
+	for range seq {
+		n++
+	}
+	return n
+}
+
+func Collect[T any](seq iter.Seq[T]) []T {
+	return slices.Collect(seq)
+}
+
+// Keys drops the values of a paired sequence.
+func Keys[K any, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values drops the keys of a paired sequence.
+func Values[K any, V any](seq iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range seq {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
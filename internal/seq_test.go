@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+// counting yields 0, 1, 2, ... up to n-1, recording how many values were
+// pulled and whether the sequence was abandoned before its end.
+func counting(n int, pulled *int, done *bool) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		defer func() { *done = true }()
+		for i := range n {
+			*pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestTakeStopsEarly(t *testing.T) {
+	tests := []struct {
+		n      int
+		want   []int
+		pulled int
+	}{
+		{-1, nil, 0},
+		{0, nil, 0},
+		{1, []int{0}, 1},
+		{3, []int{0, 1, 2}, 3},
+		{10, []int{0, 1, 2, 3, 4}, 5},
+	}
+	for _, tt := range tests {
+		pulled, done := 0, false
+		got := slices.Collect(Take(counting(5, &pulled, &done), tt.n))
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Take(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+		if pulled != tt.pulled {
+			t.Errorf("Take(%d) pulled %d values, want %d", tt.n, pulled, tt.pulled)
+		}
+	}
+	// breaking out of the loop stops the source too.
+	pulled, done := 0, false
+	for v := range Take(counting(5, &pulled, &done), 4) {
+		if v == 1 {
+			break
+		}
+	}
+	if pulled != 2 || !done {
+		t.Errorf("after break Take pulled %d values, finished %t", pulled, done)
+	}
+}
+
+func TestZipStopsEarly(t *testing.T) {
+	var pulledA, pulledB int
+	var doneA, doneB bool
+	var got []int
+	for x, y := range Zip(counting(5, &pulledA, &doneA), counting(3, &pulledB, &doneB)) {
+		got = append(got, x+10*y)
+	}
+	if !slices.Equal(got, []int{0, 11, 22}) {
+		t.Errorf("Zip() = %v, want [0 11 22]", got)
+	}
+	// a is pulled once more to find that b ran out; both must be finished.
+	if pulledA != 4 || !doneA || !doneB {
+		t.Errorf("Zip() pulled %d from a, finished a %t, b %t", pulledA, doneA, doneB)
+	}
+
+	pulledA, pulledB, doneA, doneB = 0, 0, false, false
+	for x := range Zip(counting(5, &pulledA, &doneA), counting(5, &pulledB, &doneB)) {
+		if x == 1 {
+			break
+		}
+	}
+	if pulledA != 2 || pulledB != 2 || !doneA || !doneB {
+		t.Errorf("after break Zip pulled %d and %d, finished %t and %t", pulledA, pulledB, doneA, doneB)
+	}
+}
+
+func TestWindowsAndChunk(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  iter.Seq[[]int]
+		want [][]int
+	}{
+		{"Windows 2", Windows(slices.Values([]int{1, 2, 3, 4}), 2), [][]int{{1, 2}, {2, 3}, {3, 4}}},
+		{"Windows 3", Windows(slices.Values([]int{1, 2, 3, 4}), 3), [][]int{{1, 2, 3}, {2, 3, 4}}},
+		{"Windows too long", Windows(slices.Values([]int{1, 2}), 3), nil},
+		{"Windows 0", Windows(slices.Values([]int{1, 2}), 0), nil},
+		{"Windows -1", Windows(slices.Values([]int{1, 2}), -1), nil},
+		{"Chunk 2", Chunk(slices.Values([]int{1, 2, 3, 4, 5}), 2), [][]int{{1, 2}, {3, 4}, {5}}},
+		{"Chunk exact", Chunk(slices.Values([]int{1, 2, 3, 4}), 2), [][]int{{1, 2}, {3, 4}}},
+		{"Chunk 0", Chunk(slices.Values([]int{1, 2}), 0), nil},
+		{"Chunk -1", Chunk(slices.Values([]int{1, 2}), -1), nil},
+	}
+	for _, tt := range tests {
+		var got [][]int
+		var first *int
+		for w := range tt.seq {
+			// every slice shares one buffer, so each must be cloned to keep it.
+			if first == nil {
+				first = &w[0]
+			} else if &w[0] != first {
+				t.Errorf("%s yielded a fresh slice, want the buffer reused", tt.name)
+			}
+			got = append(got, slices.Clone(w))
+		}
+		if !slices.EqualFunc(got, tt.want, slices.Equal) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSkip(t *testing.T) {
+	for n, want := range map[int][]int{-1: {0, 1, 2}, 0: {0, 1, 2}, 2: {2}, 5: nil} {
+		if got := slices.Collect(Skip(slices.Values([]int{0, 1, 2}), n)); !slices.Equal(got, want) {
+			t.Errorf("Skip(%d) = %v, want %v", n, got, want)
+		}
+	}
+}
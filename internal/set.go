@@ -0,0 +1,172 @@
+package internal
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Set is an unordered collection of distinct values. Use Sorted when the
+// order of iteration matters.
+type Set[T comparable] struct {
+	items map[T]struct{}
+}
+
+func NewSet[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{make(map[T]struct{}, len(items))}
+	for _, v := range items {
+		s.Add(v)
+	}
+	return s
+}
+
+// SetOf collects every value in seq.
+func SetOf[T comparable](seq iter.Seq[T]) *Set[T] {
+	s := NewSet[T]()
+	for v := range seq {
+		s.Add(v)
+	}
+	return s
+}
+
+// Add inserts v, reporting whether it was not already present.
+func (s *Set[T]) Add(v T) bool {
+	if _, exists := s.items[v]; exists {
+		return false
+	}
+	s.items[v] = struct{}{}
+	return true
+}
+
+func (s *Set[T]) Has(v T) bool {
+	_, exists := s.items[v]
+	return exists
+}
+
+// Remove deletes v, reporting whether it was present.
+func (s *Set[T]) Remove(v T) bool {
+	if _, exists := s.items[v]; !exists {
+		return false
+	}
+	delete(s.items, v)
+	return true
+}
+
+func (s *Set[T]) Len() int {
+	return len(s.items)
+}
+
+// All yields every value in no particular order.
+func (s *Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s.items)
+}
+
+// Sorted yields every value ordered by cmp.
+func (s *Set[T]) Sorted(cmp func(a, b T) int) iter.Seq[T] {
+	return slices.Values(slices.SortedFunc(s.All(), cmp))
+}
+
+func (s *Set[T]) Clone() *Set[T] {
+	return &Set[T]{maps.Clone(s.items)}
+}
+
+// Union returns the values in either set.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	res := s.Clone()
+	for v := range other.items {
+		res.Add(v)
+	}
+	return res
+}
+
+// Intersection returns the values in both sets.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	small, large := s, other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	res := NewSet[T]()
+	for v := range small.items {
+		if large.Has(v) {
+			res.Add(v)
+		}
+	}
+	return res
+}
+
+// Difference returns the values in s that are not in other.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	res := NewSet[T]()
+	for v := range s.items {
+		if !other.Has(v) {
+			res.Add(v)
+		}
+	}
+	return res
+}
+
+// MultiMap maps each key to the list of values added under it, in the order
+// they were added.
+type MultiMap[K comparable, V any] struct {
+	items map[K][]V
+	size  int
+}
+
+func NewMultiMap[K comparable, V any]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{items: make(map[K][]V)}
+}
+
+// Add appends v to the values under k, creating the key if needed.
+func (m *MultiMap[K, V]) Add(k K, v V) {
+	m.items[k] = append(m.items[k], v)
+	m.size++
+}
+
+// Get returns the values under k, nil if there are none. The slice belongs
+// to the map and must not be modified.
+func (m *MultiMap[K, V]) Get(k K) []V {
+	return m.items[k]
+}
+
+func (m *MultiMap[K, V]) Has(k K) bool {
+	_, exists := m.items[k]
+	return exists
+}
+
+// Remove deletes k and every value under it, reporting whether it was
+// present.
+func (m *MultiMap[K, V]) Remove(k K) bool {
+	values, exists := m.items[k]
+	if !exists {
+		return false
+	}
+	m.size -= len(values)
+	delete(m.items, k)
+	return true
+}
+
+// Len is the number of keys.
+func (m *MultiMap[K, V]) Len() int {
+	return len(m.items)
+}
+
+// Size is the number of values across every key.
+func (m *MultiMap[K, V]) Size() int {
+	return m.size
+}
+
+// All yields every key and its values, keys in no particular order.
+func (m *MultiMap[K, V]) All() iter.Seq2[K, []V] {
+	return maps.All(m.items)
+}
+
+// Sorted yields every key and its values, keys ordered by cmp.
+func (m *MultiMap[K, V]) Sorted(cmp func(a, b K) int) iter.Seq2[K, []V] {
+	return func(yield func(K, []V) bool) {
+		for _, k := range slices.SortedFunc(maps.Keys(m.items), cmp) {
+			if !yield(k, m.items[k]) {
+				return
+			}
+		}
+	}
+}
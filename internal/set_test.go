@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestSetAlgebra(t *testing.T) {
+	a, b := NewSet(1, 2, 3, 4), NewSet(3, 4, 5)
+	tests := []struct {
+		name string
+		got  *Set[int]
+		want []int
+	}{
+		{"Union", a.Union(b), []int{1, 2, 3, 4, 5}},
+		{"Intersection", a.Intersection(b), []int{3, 4}},
+		{"Intersection reversed", b.Intersection(a), []int{3, 4}},
+		{"Difference", a.Difference(b), []int{1, 2}},
+		{"Difference reversed", b.Difference(a), []int{5}},
+		{"Union empty", a.Union(NewSet[int]()), []int{1, 2, 3, 4}},
+		{"Intersection empty", a.Intersection(NewSet[int]()), []int{}},
+		{"Difference self", a.Difference(a), []int{}},
+	}
+	for _, tt := range tests {
+		if got := slices.Collect(tt.got.Sorted(cmp.Compare[int])); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	// none of the operations may change their operands.
+	if got := slices.Collect(a.Sorted(cmp.Compare[int])); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("a changed to %v", got)
+	}
+	if got := slices.Collect(b.Sorted(cmp.Compare[int])); !slices.Equal(got, []int{3, 4, 5}) {
+		t.Errorf("b changed to %v", got)
+	}
+}
+
+func TestSetMembership(t *testing.T) {
+	s := NewSet("b", "a", "b")
+	if s.Len() != 2 {
+		t.Errorf("NewSet() with a duplicate has Len %d, want 2", s.Len())
+	}
+	if s.Add("a") || !s.Add("c") {
+		t.Error("Add() reported the wrong membership")
+	}
+	if !s.Remove("a") || s.Remove("a") || s.Has("a") {
+		t.Error("Remove() reported the wrong membership")
+	}
+	clone := s.Clone()
+	clone.Add("z")
+	if s.Has("z") {
+		t.Error("adding to a clone changed the original")
+	}
+	desc := func(a, b string) int { return cmp.Compare(b, a) }
+	if got := slices.Collect(clone.Sorted(desc)); !slices.Equal(got, []string{"z", "c", "b"}) {
+		t.Errorf("Sorted(desc) = %v, want [z c b]", got)
+	}
+}
+
+func TestMultiMap(t *testing.T) {
+	m := NewMultiMap[string, int]()
+	m.Add("a", 1)
+	m.Add("b", 2)
+	m.Add("a", 3)
+	m.Add("c", 4)
+	m.Add("a", 5)
+
+	tests := []struct {
+		remove    string
+		removed   bool
+		len, size int
+	}{
+		{"", false, 3, 5},
+		{"b", true, 2, 4},
+		{"b", false, 2, 4},
+		{"a", true, 1, 1},
+		{"c", true, 0, 0},
+	}
+	for _, tt := range tests {
+		if tt.remove != "" {
+			if got := m.Remove(tt.remove); got != tt.removed {
+				t.Errorf("Remove(%q) = %t, want %t", tt.remove, got, tt.removed)
+			}
+			if m.Has(tt.remove) || m.Get(tt.remove) != nil {
+				t.Errorf("%q is still present after Remove()", tt.remove)
+			}
+		} else if got := m.Get("a"); !slices.Equal(got, []int{1, 3, 5}) {
+			t.Errorf(`Get("a") = %v, want [1 3 5]`, got)
+		}
+		if m.Len() != tt.len || m.Size() != tt.size {
+			t.Errorf("after Remove(%q): Len %d, Size %d, want %d, %d", tt.remove, m.Len(), m.Size(), tt.len, tt.size)
+		}
+	}
+	m.Add("a", 6)
+	if m.Len() != 1 || m.Size() != 1 || !slices.Equal(m.Get("a"), []int{6}) {
+		t.Errorf("re-adding a removed key gives Len %d, Size %d, values %v", m.Len(), m.Size(), m.Get("a"))
+	}
+}
@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// ErrNotImplemented is returned by a part that has not been solved yet.
+var ErrNotImplemented = errors.New("not implemented")
+
+// Answer is the result of solving one part of a puzzle.
+type Answer string
+
+func IntAnswer(n int) Answer {
+	return Answer(strconv.Itoa(n))
+}
+
+func (a Answer) String() string {
+	return string(a)
+}
+
+// Solver solves both parts of a day's puzzle. Parse is called once per input
+// and its result is handed to each part, so parts must not modify it.
+type Solver[T any] interface {
+	Parse(input []byte) (T, error)
+	Part1(input T) (Answer, error)
+	Part2(input T) (Answer, error)
+}
+
+// Explainer is implemented by solvers that can describe how they reach
+// their answers, for debugging disagreements with a reference solution.
+type Explainer[T any] interface {
+	Explain(w io.Writer, input T) error
+}
+
+// ErrNoExplanation is returned when explaining a day whose solver is not an
+// Explainer.
+var ErrNoExplanation = errors.New("no explanation available")
+
+// Day is a registered solver with its type parameter erased.
+type Day struct {
+	Number  int
+	parse   func([]byte) (any, error)
+	solve   [2]func(any) (Answer, error)
+	explain func(io.Writer, any) error
+}
+
+// Parse parses the raw puzzle input for the day.
+func (d *Day) Parse(input []byte) (any, error) {
+	return d.parse(input)
+}
+
+// Solve runs the given part against input previously returned by Parse.
+func (d *Day) Solve(part int, input any) (Answer, error) {
+	if part < 1 || part > len(d.solve) {
+		return "", fmt.Errorf("day %d has no part %d", d.Number, part)
+	}
+	return d.solve[part-1](input)
+}
+
+// Explain writes the solver's explanation of input previously returned by
+// Parse.
+func (d *Day) Explain(w io.Writer, input any) error {
+	if d.explain == nil {
+		return fmt.Errorf("day %d: %w", d.Number, ErrNoExplanation)
+	}
+	return d.explain(w, input)
+}
+
+var registry = struct {
+	sync.Mutex
+	days map[int]*Day
+}{days: make(map[int]*Day)}
+
+// Register makes a solver available under the given day. It is meant to be
+// called from the init function of each day's package and panics if the day
+// is already taken.
+func Register[T any](day int, s Solver[T]) {
+	d := &Day{
+		Number: day,
+		parse:  func(b []byte) (any, error) { return s.Parse(b) },
+		solve: [2]func(any) (Answer, error){
+			func(in any) (Answer, error) { return s.Part1(in.(T)) },
+			func(in any) (Answer, error) { return s.Part2(in.(T)) },
+		},
+	}
+	if e, ok := s.(Explainer[T]); ok {
+		d.explain = func(w io.Writer, in any) error { return e.Explain(w, in.(T)) }
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if _, exists := registry.days[day]; exists {
+		panic(fmt.Sprintf("Register() day %d registered twice", day))
+	}
+	registry.days[day] = d
+}
+
+// Lookup returns the solver registered for the given day.
+func Lookup(day int) (*Day, bool) {
+	registry.Lock()
+	defer registry.Unlock()
+	d, ok := registry.days[day]
+	return d, ok
+}
+
+// Days returns every registered day in ascending order.
+func Days() []*Day {
+	registry.Lock()
+	defer registry.Unlock()
+	days := make([]*Day, 0, len(registry.days))
+	for _, d := range registry.days {
+		days = append(days, d)
+	}
+	slices.SortFunc(days, func(a, b *Day) int { return a.Number - b.Number })
+	return days
+}